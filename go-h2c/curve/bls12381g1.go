@@ -0,0 +1,32 @@
+package curve
+
+import (
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// absZBLS12381 is the absolute value of the BLS parameter z = -0xd201000000010000
+// of the BLS12-381 curves.
+var absZBLS12381 = GF.FromType("0xd201000000010000")
+
+// betaBLS12381G1 is the primitive cube root of unity in Fp such that the
+// endomorphism sigma(x, y) = (beta * x, y) acts on G1 as the multiplication
+// by -z^2.
+const betaBLS12381G1 = "0x5f19672fdf76ce51ba69c6076a0f77eaddb3a93be6f89688de17d813620a00022e01fffffffefffe"
+
+// sigma is the endomorphism sigma(x, y) = (beta * x, y) of BLS12381G1.
+func (e *WECurve) sigma(p Point) Point {
+	if p.IsIdentity() {
+		return e.Identity()
+	}
+	P := p.(*ptWe)
+	return &ptWe{e, &afPoint{x: e.F.Mul(e.F.Elt(betaBLS12381G1), P.x), y: P.y.Copy()}}
+}
+
+// isInSubgroupBLS12381G1 returns true if sigma(P) = -[z^2]P, which holds if
+// and only if P is in G1 (Scott, "A note on group membership tests for G1, G2
+// and GT on BLS pairing-friendly curves").
+func (e *WECurve) isInSubgroupBLS12381G1(p Point) bool {
+	zP := e.scalarMultBinary(p, absZBLS12381)
+	z2P := e.Neg(e.scalarMultBinary(zP, absZBLS12381))
+	return e.sigma(p).IsEqual(z2P)
+}
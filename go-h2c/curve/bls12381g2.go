@@ -0,0 +1,70 @@
+package curve
+
+import (
+	"math/big"
+	"sync"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// psiBLS12381G2 holds the constants of the endomorphism psi of BLS12381G2.
+var psiBLS12381G2 struct {
+	once   sync.Once
+	c1, c2 GF.Elt
+	p      *big.Int
+}
+
+// psi is the endomorphism psi(x, y) = (c1 * frobenius(x), c2 * frobenius(y))
+// of BLS12381G2 (RFC 9380, Section 8.8.2), where
+//
+//	c1 = 1 / (1 + I)^((p - 1) / 3)
+//	c2 = 1 / (1 + I)^((p - 1) / 2)
+func (e *WECurve) psi(p Point) Point {
+	if p.IsIdentity() {
+		return e.Identity()
+	}
+	F := e.F
+	c := &psiBLS12381G2
+	c.once.Do(func() {
+		c.p = F.P()
+		onePlusI := F.Elt([]interface{}{1, 1})
+		pMinus1 := new(big.Int).Sub(c.p, big.NewInt(1))
+		e3 := new(big.Int).Div(pMinus1, big.NewInt(3))
+		e2 := new(big.Int).Div(pMinus1, big.NewInt(2))
+		c.c1 = F.Inv(F.Exp(onePlusI, e3))
+		c.c2 = F.Inv(F.Exp(onePlusI, e2))
+	})
+	x := F.Mul(c.c1, F.Exp(p.X(), c.p))
+	y := F.Mul(c.c2, F.Exp(p.Y(), c.p))
+	return &ptWe{e, &afPoint{x: x, y: y}}
+}
+
+// isInSubgroupBLS12381G2 returns true if psi(P) = [z]P, where z is the BLS
+// parameter, which holds if and only if P is in G2 (Scott, "A note on group
+// membership tests for G1, G2 and GT on BLS pairing-friendly curves").
+func (e *WECurve) isInSubgroupBLS12381G2(p Point) bool {
+	zP := e.Neg(e.scalarMultBinary(p, absZBLS12381))
+	return e.psi(p).IsEqual(zP)
+}
+
+// clearCofactorBLS12381G2 multiplies by the effective cofactor h_eff of
+// BLS12381G2 using the endomorphism psi as in RFC 9380 (Appendix G.3), which
+// is the method of Budroni and Pintore.
+func clearCofactorBLS12381G2(ec EllCurve, P Point) Point {
+	e := ec.(*WECurve)
+	// c1 = -15132376222941642752 is the BLS parameter, the scalar
+	// multiplication by c1 is computed as -[|c1|]P. Since c1 is public, it
+	// uses the double-and-add method.
+	mulC1 := func(Q Point) Point { return e.Neg(e.scalarMultBinary(Q, absZBLS12381)) }
+
+	t1 := mulC1(P)             // 1.  t1 = c1 * P
+	t2 := e.psi(P)             // 2.  t2 = psi(P)
+	t3 := e.Double(P)          // 3.  t3 = 2 * P
+	t3 = e.psi(e.psi(t3))      // 4.  t3 = psi2(t3)
+	t3 = e.Add(t3, e.Neg(t2))  // 5.  t3 = t3 - t2
+	t2 = e.Add(t1, t2)         // 6.  t2 = t1 + t2
+	t2 = mulC1(t2)             // 7.  t2 = c1 * t2
+	t3 = e.Add(t3, t2)         // 8.  t3 = t3 + t2
+	t3 = e.Add(t3, e.Neg(t1))  // 9.  t3 = t3 - t1
+	return e.Add(t3, e.Neg(P)) // 10.  Q = t3 - P
+}
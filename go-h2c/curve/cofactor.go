@@ -0,0 +1,41 @@
+package curve
+
+import "math/big"
+
+// CofactorClearing is a strategy to map points on the curve e into the
+// subgroup of prime order, which is used by ClearCofactor.
+type CofactorClearing func(e EllCurve, p Point) Point
+
+// ClearCofactorBy returns a strategy that multiplies points by the scalar h.
+// Besides the cofactor, h can be the effective cofactor h_eff given by
+// RFC 9380 for a curve, which is a multiple of the cofactor that is cheaper
+// to compute or that yields a faster method.
+func ClearCofactorBy(h *big.Int) CofactorClearing {
+	h = new(big.Int).Set(h)
+	return func(e EllCurve, p Point) Point { return scalarMult(e, p, h) }
+}
+
+// SetCofactorClearing sets the strategy used by ClearCofactor. If c is nil,
+// points are multiplied by the cofactor.
+func (e *params) SetCofactorClearing(c CofactorClearing) { e.clear = c }
+
+// scalarMult returns [k]P for a public scalar k, using the variable-time
+// scalar multiplication of the curve if it has one.
+func scalarMult(e EllCurve, p Point, k *big.Int) Point {
+	switch ec := e.(type) {
+	case *WECurve:
+		return ec.scalarMultBinary(p, k)
+	case *TECurve:
+		return ec.scalarMultBinary(p, k)
+	case *MTCurve:
+		return ec.scalarMultLadder(p, k, k.BitLen())
+	}
+	Q := e.Identity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		Q = e.Double(Q)
+		if k.Bit(i) != 0 {
+			Q = e.Add(Q, p)
+		}
+	}
+	return Q
+}
@@ -13,6 +13,8 @@ type params struct {
 	A, B, D GF.Elt
 	R       *big.Int
 	H       *big.Int
+	clear   CofactorClearing
+	glv     *GLV
 }
 
 func (e *params) String() string {
@@ -22,6 +24,13 @@ func (e *params) Field() GF.Field    { return e.F }
 func (e *params) Order() *big.Int    { return e.R }
 func (e *params) Cofactor() *big.Int { return e.H }
 
+func mustPoint(p Point, err error) Point {
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
 // afPoint is an affine point.
 type afPoint struct{ x, y GF.Elt }
 
@@ -3,11 +3,32 @@
 package curve
 
 import (
+	"errors"
 	"math/big"
 
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 )
 
+// Errors returned by the constructors of curves and points, and by the
+// decoding of points.
+var (
+	// ErrInvalidCurve is returned when the parameters do not define an
+	// elliptic curve, for example, if the curve is singular.
+	ErrInvalidCurve = errors.New("curve: invalid curve parameters")
+	// ErrUnsupported is returned for curves with no implementation.
+	ErrUnsupported = errors.New("curve: curve not supported")
+	// ErrNotOnCurve is returned when the coordinates of a point do not
+	// satisfy the curve equation.
+	ErrNotOnCurve = errors.New("curve: point not on curve")
+	// ErrNotInSubgroup is returned when a point is not in the subgroup of
+	// prime order.
+	ErrNotInSubgroup = errors.New("curve: point not in the prime-order subgroup")
+	// ErrInvalidEncoding is returned when an encoding of a point is malformed.
+	ErrInvalidEncoding = errors.New("curve: invalid point encoding")
+	// ErrInfinity is returned when the point at infinity cannot be represented.
+	ErrInfinity = errors.New("curve: point at infinity")
+)
+
 // Point represents an elliptic curve point.
 type Point interface {
 	Copy() Point
@@ -24,8 +45,12 @@ type EllCurve interface {
 	Order() *big.Int
 	Cofactor() *big.Int
 	NewPoint(x, y GF.Elt) Point
+	NewPointE(x, y GF.Elt) (Point, error)
 	// Predicates
 	IsOnCurve(Point) bool
+	// IsInSubgroup returns true if the point, which must be on the curve,
+	// is in the subgroup of prime order.
+	IsInSubgroup(Point) bool
 	IsEqual(EllCurve) bool
 	// Arithmetic operations
 	Identity() Point
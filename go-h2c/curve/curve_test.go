@@ -1,10 +1,21 @@
 package curve_test
 
 import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"math/big"
 	"testing"
 
 	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 	"github.com/armfazh/hash-to-curve-ref/go-h2c/internal/toy"
+	"golang.org/x/crypto/sha3"
 )
 
 func TestCurves(t *testing.T) {
@@ -33,6 +44,410 @@ func testAdd(t *testing.T, e C.EllCurve, g C.Point) {
 			}
 		}
 	}
+	// T holds the multiples of g up to its order n.
+	T = []C.Point{e.Identity()}
+	for P := g; !P.IsIdentity(); P = e.Add(P, g) {
+		T = append(T, P)
+	}
+	n := uint64(len(T))
+	for i := uint64(0); i < n; i++ {
+		for j := uint64(0); j < n; j++ {
+			got := e.Add(T[i], T[j])
+			want := T[(i+j)%n]
+			if !got.IsEqual(want) {
+				t.Fatalf("[%v]P+[%v]P\ngot:  %v\nwant: %v", i, j, got, want)
+			}
+		}
+		if got, want := e.Double(T[i]), T[(2*i)%n]; !got.IsEqual(want) {
+			t.Fatalf("2*[%v]P\ngot:  %v\nwant: %v", i, got, want)
+		}
+	}
+	if ec, ok := e.(interface {
+		ScalarMult(C.Point, *big.Int) C.Point
+	}); ok {
+		for k := uint64(0); k < 2*n; k++ {
+			got := ec.ScalarMult(g, new(big.Int).SetUint64(k))
+			want := T[k%n]
+			if !got.IsEqual(want) {
+				t.Fatalf("[%v]P\ngot:  %v\nwant: %v", k, got, want)
+			}
+		}
+	}
+}
+
+func TestMontgomeryLadder(t *testing.T) {
+	for name, EC := range toy.ToyCurves {
+		e, ok := EC.E.(C.M)
+		if !ok {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			F := e.Field()
+			for P := EC.P; !P.IsIdentity(); P = e.Add(P, EC.P) {
+				for k := int64(0); k < 2*e.Order().Int64(); k++ {
+					kk := big.NewInt(k)
+					Q := e.ScalarMult(P, kk)
+					got := e.ScalarMultX(P.X(), kk)
+					want := F.Zero()
+					if !Q.IsIdentity() {
+						want = Q.X()
+					}
+					if !F.AreEqual(got, want) {
+						t.Fatalf("[%v]%v\ngot:  %v\nwant: %v", k, P, got, want)
+					}
+				}
+				got := e.ClearCofactor(P)
+				want := e.ScalarMult(P, e.Cofactor())
+				if !got.IsEqual(want) {
+					t.Fatalf("got:  %v\nwant: %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	// E is not complete since D is a square, and #E = 7*8.
+	F := GF.NewFp(0, 53)
+	e := C.NewEdwards(C.Custom, F, F.One(), F.Elt(4), big.NewInt(7), big.NewInt(8))
+	for _, v := range []struct {
+		e    interface{ IsComplete() bool }
+		want bool
+	}{
+		{C.P256.Get().(C.W), true},
+		{C.BLS12381G1.Get().(C.W), true},
+		{C.Edwards25519.Get().(C.T), true},
+		{C.Edwards448.Get().(C.T), true},
+		{toy.ToyCurves["W3"].E.(C.W), false},
+		{toy.ToyCurves["E0"].E.(C.T), true},
+		{e, false},
+	} {
+		if got := v.e.IsComplete(); got != v.want {
+			t.Fatalf("%v\ngot:  %v\nwant: %v", v.e, got, v.want)
+		}
+	}
+
+	testAdd(t, e, e.NewPoint(F.Elt(5), F.Elt(28)))
+
+	// The sum of P and Q is a point at infinity, which the affine formulas
+	// cannot represent.
+	P := e.NewPoint(F.Elt(3), F.Elt(20))
+	Q := e.NewPoint(F.Elt(4), F.Elt(9))
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected a panic on a sum at infinity")
+		}
+	}()
+	e.Add(P, Q)
+}
+
+func TestToWeierstrassC(t *testing.T) {
+	for name, EC := range toy.ToyCurves {
+		var r C.RationalMap
+		switch e := EC.E.(type) {
+		case C.T:
+			r = e.ToWeierstrassC()
+		case C.M:
+			r = e.ToWeierstrassC()
+		default:
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			e0, e1 := r.Domain(), r.Codomain()
+			F := e0.Field()
+			for P := EC.P; !P.IsIdentity(); P = e0.Add(P, EC.P) {
+				if F.IsZero(P.X()) {
+					continue // The map is not defined by the formulas at x=0.
+				}
+				Q := r.Push(P)
+				if !e1.IsOnCurve(Q) {
+					t.Fatalf("point not in the curve: %v -> %v", P, Q)
+				}
+				if got := r.Pull(Q); !got.IsEqual(P) {
+					t.Fatalf("got:  %v\nwant: %v", got, P)
+				}
+			}
+		})
+	}
+}
+
+func TestSubgroup(t *testing.T) {
+	for name, EC := range toy.ToyCurves {
+		t.Run(name, func(t *testing.T) {
+			e := EC.E
+			F := e.Field()
+			p := F.P().Int64()
+			points := []C.Point{e.Identity()}
+			for x := int64(0); x < p; x++ {
+				for y := int64(0); y < p; y++ {
+					if P, err := e.NewPointE(F.Elt(x), F.Elt(y)); err == nil && !P.IsIdentity() {
+						points = append(points, P)
+					}
+				}
+			}
+			n := int64(0)
+			for _, P := range points {
+				if e.IsInSubgroup(P) {
+					n++
+				}
+				if Q := e.ClearCofactor(P); !e.IsInSubgroup(Q) {
+					t.Fatalf("point not in subgroup: %v", Q)
+				}
+			}
+			if want := e.Order().Int64(); n != want {
+				t.Fatalf("got: %v points in subgroup want: %v", n, want)
+			}
+		})
+	}
+
+	for _, id := range []C.CurveID{C.BLS12381G1, C.BLS12381G2} {
+		e := id.Get().(C.W)
+		F := e.Field()
+		for i := 0; i < 16; i++ {
+			var x GF.Elt
+			if F.Ext() == 1 {
+				x = F.Elt(i)
+			} else {
+				x = F.Elt([]interface{}{i, 1})
+			}
+			y2 := e.EvalRHS(x)
+			if !F.IsSquare(y2) {
+				continue
+			}
+			P := e.NewPoint(x, F.Sqrt(y2))
+			for _, Q := range []C.Point{P, e.ClearCofactor(P)} {
+				got := e.IsInSubgroup(Q)
+				want := mulBinary(e, Q, e.Order()).IsIdentity()
+				if got != want {
+					t.Fatalf("%v: %v\ngot: %v want: %v", id, Q, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestClearCofactorBLS12381(t *testing.T) {
+	for _, v := range []struct {
+		id C.CurveID
+		// hEff is the effective cofactor of the curve (RFC 9380, Section 8.8).
+		hEff *big.Int
+	}{
+		{C.BLS12381G1, GF.FromType("0xd201000000010001")},
+		{C.BLS12381G2, GF.FromType("0xbc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551")},
+	} {
+		e := v.id.Get().(C.W)
+		F := e.Field()
+		for i := 0; i < 4; i++ {
+			x := F.Rand(rand.Reader)
+			y2 := e.EvalRHS(x)
+			if !F.IsSquare(y2) {
+				i--
+				continue
+			}
+			P := e.NewPoint(x, F.Sqrt(y2))
+			got := e.ClearCofactor(P)
+			want := mulBinary(e, P, v.hEff)
+			if !got.IsEqual(want) {
+				t.Fatalf("%v\ngot:  %v\nwant: %v", v.id, got, want)
+			}
+			if !e.ScalarMult(got, e.Order()).IsIdentity() {
+				t.Fatalf("%v: point not in the subgroup: %v", v.id, got)
+			}
+			if hP := mulBinary(e, P, e.Cofactor()); !e.ScalarMult(hP, e.Order()).IsIdentity() {
+				t.Fatalf("%v: wrong cofactor", v.id)
+			}
+		}
+	}
+}
+
+// mulBinary returns [k]P using the double-and-add method, which works for
+// every point on the curve.
+func mulBinary(e C.EllCurve, P C.Point, k *big.Int) C.Point {
+	Q := e.Identity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		Q = e.Double(Q)
+		if k.Bit(i) != 0 {
+			Q = e.Add(Q, P)
+		}
+	}
+	return Q
+}
+
+// findPoint returns a point on the curve other than the identity.
+func findPoint(e C.EllCurve) C.Point {
+	F := e.Field()
+	for i := int64(2); ; i++ {
+		var x, y2 GF.Elt
+		switch ec := e.(type) {
+		case C.W:
+			x = F.Elt(i)
+			y2 = ec.EvalRHS(x)
+		case C.M:
+			// By^2 = x^3+Ax^2+x
+			x = F.Elt(i)
+			y2 = F.Mul(F.Add(F.Mul(F.Add(x, ec.A), x), F.One()), x)
+			y2 = F.Mul(y2, F.Inv(ec.B))
+		case C.T:
+			// x^2 = (1-y^2)/(A-Dy^2), where y = i.
+			y := F.Elt(i)
+			x2 := F.Sub(F.One(), F.Sqr(y))
+			x2 = F.Mul(x2, F.Inv(F.Sub(ec.A, F.Mul(ec.D, F.Sqr(y)))))
+			if F.IsSquare(x2) {
+				return e.NewPoint(F.Sqrt(x2), y)
+			}
+			continue
+		}
+		if F.IsSquare(y2) {
+			return e.NewPoint(x, F.Sqrt(y2))
+		}
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	for _, id := range []C.CurveID{
+		C.P256,
+		C.SECP256K1,
+		C.BLS12381G1,
+		C.BLS12381G2,
+		C.Curve25519,
+		C.Edwards25519,
+		C.Edwards448,
+	} {
+		e := id.Get()
+		ec := e.(interface {
+			ScalarMult(C.Point, *big.Int) C.Point
+		})
+		P := findPoint(e)
+		r := e.Order()
+		scalars := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			big.NewInt(2),
+			big.NewInt(-3),
+			new(big.Int).Sub(r, big.NewInt(1)),
+			new(big.Int).Lsh(r, 2),
+		}
+		for i := 0; i < 2; i++ {
+			k, _ := rand.Int(rand.Reader, r)
+			scalars = append(scalars, k)
+		}
+		for _, k := range scalars {
+			got := ec.ScalarMult(P, k)
+			want := mulBinary(e, P, new(big.Int).Abs(k))
+			if k.Sign() < 0 {
+				want = e.Neg(want)
+			}
+			if !got.IsEqual(want) {
+				t.Fatalf("%v: [%v]P\ngot:  %v\nwant: %v", id, k, got, want)
+			}
+		}
+	}
+}
+
+func TestGLV(t *testing.T) {
+	for _, id := range []C.CurveID{C.SECP256K1} {
+		e := id.Get().(C.W)
+		F := e.Field()
+		r := e.Order()
+		x := F.One()
+		for !F.IsSquare(e.EvalRHS(x)) {
+			x = F.Add(x, F.One())
+		}
+		P := e.ClearCofactor(e.NewPoint(x, F.Sqrt(e.EvalRHS(x))))
+		scalars := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			big.NewInt(-1),
+			new(big.Int).Sub(r, big.NewInt(1)),
+			new(big.Int).Set(r),
+			new(big.Int).Add(r, big.NewInt(5)),
+		}
+		for i := 0; i < 16; i++ {
+			k, _ := rand.Int(rand.Reader, r)
+			scalars = append(scalars, k)
+		}
+		for _, k := range scalars {
+			got := e.ScalarMult(P, k)
+			want := mulBinary(e, P, new(big.Int).Mod(k, r))
+			if !got.IsEqual(want) {
+				t.Fatalf("%v: [%v]P\ngot:  %v\nwant: %v", id, k, got, want)
+			}
+		}
+	}
+
+	// The endomorphism of BLS12381G1 only acts as lambda on G1, so GLV must
+	// agree with the window method on G1 and must not be used outside of it.
+	g1 := C.BLS12381G1.Get().(*C.WECurve)
+	w1 := C.BLS12381G1.Get().(*C.WECurve)
+	if err := w1.SetGLV(nil); err != nil {
+		t.Fatal(err)
+	}
+	F := g1.Field()
+	x := F.One()
+	for !F.IsSquare(g1.EvalRHS(x)) {
+		x = F.Add(x, F.One())
+	}
+	P := g1.NewPoint(x, F.Sqrt(g1.EvalRHS(x)))
+	Q := g1.ClearCofactor(P)
+	if g1.IsInSubgroup(P) || !g1.IsInSubgroup(Q) {
+		t.Fatal("expected P outside of G1 and Q in G1")
+	}
+	for i := 0; i < 16; i++ {
+		k, _ := rand.Int(rand.Reader, g1.Order())
+		for _, R := range []C.Point{P, Q} {
+			got := g1.ScalarMult(R, k)
+			want := w1.ScalarMult(w1.NewPoint(R.X(), R.Y()), k)
+			if !got.IsEqual(want) {
+				t.Fatalf("%v: [%v]P\ngot:  %v\nwant: %v", g1.Id, k, got, want)
+			}
+		}
+	}
+
+	F = GF.NewFp(0, 59)
+	e := C.NewWeierstrass(C.Custom, F, F.Zero(), F.One(), big.NewInt(5), big.NewInt(12))
+	if err := e.SetGLV(&C.GLV{
+		Beta: F.One(), Lambda: big.NewInt(1),
+		A1: big.NewInt(1), B1: big.NewInt(-1), A2: big.NewInt(5), B2: big.NewInt(0),
+	}); !errors.Is(err, C.ErrInvalidCurve) {
+		t.Fatalf("expected an error on invalid GLV parameters: %v", err)
+	}
+}
+
+func TestCofactorClearing(t *testing.T) {
+	F := GF.NewFp(0, 59)
+	e := C.NewWeierstrass(C.Custom, F, F.One(), F.One(), big.NewInt(7), big.NewInt(9))
+	P := e.NewPoint(F.Zero(), F.One())
+	h := big.NewInt(18)
+	e.SetCofactorClearing(C.ClearCofactorBy(h))
+	if got, want := e.ClearCofactor(P), e.ScalarMult(P, h); !got.IsEqual(want) {
+		t.Fatalf("got:  %v\nwant: %v", got, want)
+	}
+	e.SetCofactorClearing(nil)
+	if got, want := e.ClearCofactor(P), e.ScalarMult(P, e.Cofactor()); !got.IsEqual(want) {
+		t.Fatalf("got:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestScalarField(t *testing.T) {
+	for _, id := range []C.CurveID{C.P256, C.Curve448, C.BLS12381G2} {
+		S := id.ScalarField()
+		if S.P().Cmp(id.Get().Order()) != 0 {
+			t.Fatalf("curve: %v wrong scalar field %v", id, S)
+		}
+		if id.ScalarField() != S {
+			t.Fatalf("curve: %v scalar field is built again", id)
+		}
+		CT, err := id.ScalarFieldE(GF.ConstantTime)
+		if err != nil || CT.P().Cmp(S.P()) != 0 {
+			t.Fatalf("curve: %v wrong constant-time scalar field %v: %v", id, CT, err)
+		}
+	}
+	if _, err := C.CurveID(255).ScalarFieldE(GF.BigInt); !errors.Is(err, C.ErrUnsupported) {
+		t.Fatalf("expected an error on unknown curve: %v", err)
+	}
+	if _, err := C.P256.ScalarFieldE(GF.Specialized); !errors.Is(err, C.ErrUnsupported) {
+		t.Fatalf("expected an error on specialized backend: %v", err)
+	}
 }
 
 func BenchmarkCurve(b *testing.B) {
@@ -52,3 +467,351 @@ func BenchmarkCurve(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkScalarMult(b *testing.B) {
+	for name, id := range map[string]C.CurveID{
+		"P256":       C.P256,
+		"SECP256K1":  C.SECP256K1,
+		"BLS12381G1": C.BLS12381G1,
+	} {
+		e := id.Get().(C.W)
+		F := e.Field()
+		x := F.One()
+		for !F.IsSquare(e.EvalRHS(x)) {
+			x = F.Add(x, F.One())
+		}
+		P := e.NewPoint(x, F.Sqrt(e.EvalRHS(x)))
+		k := new(big.Int).Sub(e.Order(), big.NewInt(1))
+		b.Run(name+"/ScalarMult", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.ScalarMult(P, k)
+			}
+		})
+		b.Run(name+"/ClearCofactor", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.ClearCofactor(P)
+			}
+		})
+	}
+}
+
+func BenchmarkEdwards(b *testing.B) {
+	for name, id := range map[string]C.CurveID{
+		"Edwards25519": C.Edwards25519,
+		"Edwards448":   C.Edwards448,
+	} {
+		e := id.Get().(C.T)
+		F := e.Field()
+		// x^2 = (1-y^2)/(A-Dy^2), starting from y = 2 to avoid the identity.
+		x2 := func(y GF.Elt) GF.Elt {
+			y2 := F.Sqr(y)
+			return F.Mul(F.Sub(F.One(), y2), F.Inv(F.Sub(e.A, F.Mul(e.D, y2))))
+		}
+		y := F.Elt(2)
+		for !F.IsSquare(x2(y)) {
+			y = F.Add(y, F.One())
+		}
+		x := F.Sqrt(x2(y))
+		P := e.NewPoint(x, y)
+		k := new(big.Int).Sub(e.Order(), big.NewInt(1))
+		b.Run(name+"/ScalarMult", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.ScalarMult(P, k)
+			}
+		})
+		b.Run(name+"/ClearCofactor", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.ClearCofactor(P)
+			}
+		})
+	}
+}
+
+func TestSEC1(t *testing.T) {
+	// P256 generator, SEC 2, Section 2.4.2.
+	e := C.P256.Get().(C.W)
+	F := e.Field()
+	G := e.NewPoint(
+		F.Elt("0x6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296"),
+		F.Elt("0x4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5"))
+	want, _ := hex.DecodeString("036b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296")
+	if got := e.Marshal(G, true); !bytes.Equal(got, want) {
+		t.Fatalf("got: %x\nwant: %x\n", got, want)
+	}
+	want, _ = hex.DecodeString("046b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c2964fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5")
+	if got, _ := G.(encoding.BinaryMarshaler).MarshalBinary(); !bytes.Equal(got, want) {
+		t.Fatalf("got: %x\nwant: %x\n", got, want)
+	}
+
+	for _, id := range []C.CurveID{C.P256, C.P384, C.P521, C.SECP256K1, C.BLS12381G1, C.BLS12381G2} {
+		e := id.Get().(C.W)
+		F := e.Field()
+		for i := 0; i < 16; i++ {
+			x := F.Rand(rand.Reader)
+			for !F.IsSquare(e.EvalRHS(x)) {
+				x = F.Add(x, F.One())
+			}
+			P := e.NewPoint(x, F.Sqrt(e.EvalRHS(x)))
+			if e.Cofactor().Cmp(big.NewInt(1)) != 0 {
+				if _, err := e.Unmarshal(e.Marshal(P, true)); err == nil {
+					t.Fatalf("%v: accepted point not in the subgroup: %v\n", id, P)
+				}
+				P = e.ClearCofactor(P)
+			}
+			for _, Q := range []C.Point{P, e.Neg(P), e.Identity()} {
+				for _, compress := range []bool{true, false} {
+					b := e.Marshal(Q, compress)
+					got, err := e.Unmarshal(b)
+					if err != nil || !got.IsEqual(Q) {
+						t.Fatalf("%v: got: %v want: %v err: %v\n", id, got, Q, err)
+					}
+				}
+			}
+			b := e.Marshal(P, false)
+			b[len(b)-1] ^= 1
+			if _, err := e.Unmarshal(b); err == nil {
+				t.Fatalf("%v: accepted point not on curve\n", id)
+			}
+			b[0] = 0x05
+			if _, err := e.Unmarshal(b); err == nil {
+				t.Fatalf("%v: accepted wrong prefix\n", id)
+			}
+		}
+	}
+
+	// A point with y = 0 has even sign, so the prefix 0x03 is malformed.
+	W1 := toy.ToyCurves["W1"].E.(C.W)
+	b := W1.Marshal(W1.NewPoint(W1.Field().Elt(-1), W1.Field().Zero()), true)
+	b[0] = 0x03
+	if _, err := W1.Unmarshal(b); !errors.Is(err, C.ErrInvalidEncoding) {
+		t.Fatalf("expected an error on odd sign of y = 0: %v", err)
+	}
+}
+
+func TestRFC8032(t *testing.T) {
+	// Public key of a random Ed25519 key generated by crypto/ed25519.
+	e := C.Edwards25519.Get().(C.T)
+	B25519, _ := hex.DecodeString("5866666666666666666666666666666666666666666666666666666666666666")
+	G, err := e.Unmarshal(B25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	h := sha512.Sum512(priv.Seed())
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	s := leInt(h[:32])
+	if got := e.Marshal(e.ScalarMult(G, s)); !bytes.Equal(got, pub) {
+		t.Fatalf("got: %x\nwant: %x\n", got, pub)
+	}
+
+	// Ed448 test vector "Blank", RFC 8032 (Section 7.4).
+	e = C.Edwards448.Get().(C.T)
+	F := e.Field()
+	G = e.NewPoint(
+		F.Elt("224580040295924300187604334099896036246789641632564134246125461686950415467406032909029192869357953282578032075146446173674602635247710"),
+		F.Elt("298819210078481492676017930443930673437544040154080242095928241372331506189835876003536878655418784733982303233503462500531545062832660"))
+	sk, _ := hex.DecodeString("6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b")
+	pub, _ = hex.DecodeString("5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180")
+	k := make([]byte, 114)
+	sha3.ShakeSum256(k, sk)
+	k[0] &= 252
+	k[55] |= 128
+	k[56] = 0
+	if got := e.Marshal(e.ScalarMult(G, leInt(k[:57]))); !bytes.Equal(got, pub) {
+		t.Fatalf("got: %x\nwant: %x\n", got, pub)
+	}
+
+	bases := map[C.CurveID]C.Point{C.Edwards448: G}
+	if bases[C.Edwards25519], err = C.Edwards25519.Get().(C.T).Unmarshal(B25519); err != nil {
+		t.Fatal(err)
+	}
+	for id, G := range bases {
+		e := id.Get().(C.T)
+		for i := 0; i < 16; i++ {
+			k, _ := rand.Int(rand.Reader, e.Order())
+			P := e.ScalarMult(G, k)
+			for _, Q := range []C.Point{P, e.Neg(P), e.Identity()} {
+				got, err := e.Unmarshal(e.Marshal(Q))
+				if err != nil || !got.IsEqual(Q) {
+					t.Fatalf("%v: got: %v want: %v err: %v\n", id, got, Q, err)
+				}
+			}
+		}
+		b := bytes.Repeat([]byte{0xff}, len(e.Marshal(G)))
+		b[len(b)-1] = 0x7f
+		if _, err := e.Unmarshal(b); err == nil {
+			t.Fatalf("%v: accepted non-canonical y-coordinate\n", id)
+		}
+	}
+}
+
+func TestRFC7748(t *testing.T) {
+	clamp := func(k []byte) *big.Int {
+		k = append([]byte(nil), k...)
+		if len(k) == 32 {
+			k[0] &= 248
+			k[31] &= 127
+			k[31] |= 64
+		} else {
+			k[0] &= 252
+			k[55] |= 128
+		}
+		return leInt(k)
+	}
+	// X25519 and X448 test vectors, RFC 7748 (Section 5.2).
+	for _, v := range []struct {
+		id         C.CurveID
+		k, u, want string
+	}{
+		{C.Curve25519,
+			"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+			"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+			"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552"},
+		{C.Curve25519,
+			"4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+			"e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+			"95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957"},
+		{C.Curve448,
+			"3d262fddf9ec8e88495266fea19a34d28882acef045104d0d1aae121700a779c984c24f8cdd78fbff44943eba368f54b29259a4f1c600ad3",
+			"06fce640fa3487bfda5f6cf2d5263f8aad88334cbd07437f020f08f9814dc031ddbdc38c19c6da2583fa5429db94ada18aa7a7fb4ef8a086",
+			"ce3e4ff95a60dc6697da1db1d85e6afbdf79b50a2412d7546d5f239fe14fbaadeb445fc66a01b0779d98223961111e21766282f73dd96b6f"},
+	} {
+		e := v.id.Get().(C.M)
+		k, _ := hex.DecodeString(v.k)
+		u, _ := hex.DecodeString(v.u)
+		want, _ := hex.DecodeString(v.want)
+		x, err := e.UnmarshalX(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := e.MarshalX(e.ScalarMultX(x, clamp(k))); !bytes.Equal(got, want) {
+			t.Fatalf("%v: got: %x\nwant: %x\n", v.id, got, want)
+		}
+	}
+
+	// Public key of a random X25519 key generated by crypto/ecdh.
+	e := C.Curve25519.Get().(C.M)
+	priv, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	nine := e.Field().Elt(9)
+	if got, want := e.MarshalX(e.ScalarMultX(nine, clamp(priv.Bytes()))), priv.PublicKey().Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("got: %x\nwant: %x\n", got, want)
+	}
+
+	for _, id := range []C.CurveID{C.Curve25519, C.Curve448} {
+		e := id.Get().(C.M)
+		F := e.Field()
+		for i := 0; i < 16; i++ {
+			x := F.Rand(rand.Reader)
+			b := e.MarshalX(x)
+			if got, err := e.UnmarshalX(b); err != nil || !F.AreEqual(got, x) {
+				t.Fatalf("%v: got: %v want: %v err: %v\n", id, got, x, err)
+			}
+			P, err := e.Unmarshal(b)
+			if isSquare := F.IsSquare(F.Mul(F.Add(F.Mul(F.Add(x, e.A), x), F.One()), x)); isSquare != (err == nil) {
+				t.Fatalf("%v: x: %v err: %v\n", id, x, err)
+			}
+			if err == nil && !bytes.Equal(e.Marshal(P), b) {
+				t.Fatalf("%v: got: %x\nwant: %x\n", id, e.Marshal(P), b)
+			}
+		}
+		b := e.MarshalX(F.One())
+		if _, err := e.UnmarshalX(b[1:]); !errors.Is(err, C.ErrInvalidEncoding) {
+			t.Fatalf("%v: expected an error on short encoding: %v", id, err)
+		}
+		if _, err := e.Unmarshal(append(b, 0)); !errors.Is(err, C.ErrInvalidEncoding) {
+			t.Fatalf("%v: expected an error on long encoding: %v", id, err)
+		}
+	}
+}
+
+// leInt returns the integer encoded in little-endian order by b.
+func leInt(b []byte) *big.Int {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(r)
+}
+
+func TestZCash(t *testing.T) {
+	// Generators of G1 and G2, draft-irtf-cfrg-pairing-friendly-curves.
+	g1, _ := hex.DecodeString("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	g1y, _ := hex.DecodeString("08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")
+	g2, _ := hex.DecodeString("93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8")
+	e := C.BLS12381G1.Get().(C.W)
+	G, err := e.UnmarshalZCash(g1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(append([]byte{g1[0] &^ 0xe0}, g1[1:]...), g1y...)
+	if got := e.MarshalZCash(G, false); !bytes.Equal(got, want) {
+		t.Fatalf("got: %x\nwant: %x\n", got, want)
+	}
+
+	for id, g := range map[C.CurveID][]byte{C.BLS12381G1: g1, C.BLS12381G2: g2} {
+		e := id.Get().(C.W)
+		F := e.Field()
+		G, err := e.UnmarshalZCash(g)
+		if err != nil {
+			t.Fatalf("%v: %v\n", id, err)
+		}
+		if got := e.MarshalZCash(G, true); !bytes.Equal(got, g) {
+			t.Fatalf("%v: got: %x\nwant: %x\n", id, got, g)
+		}
+		for i := 0; i < 8; i++ {
+			k, _ := rand.Int(rand.Reader, e.Order())
+			P := e.ScalarMult(G, k)
+			for _, Q := range []C.Point{P, e.Neg(P), e.Identity()} {
+				for _, compress := range []bool{true, false} {
+					got, err := e.UnmarshalZCash(e.MarshalZCash(Q, compress))
+					if err != nil || !got.IsEqual(Q) {
+						t.Fatalf("%v: got: %v want: %v err: %v\n", id, got, Q, err)
+					}
+				}
+			}
+			x := F.Rand(rand.Reader)
+			for !F.IsSquare(e.EvalRHS(x)) {
+				x = F.Add(x, F.One())
+			}
+			Q := e.NewPoint(x, F.Sqrt(e.EvalRHS(x)))
+			if _, err := e.UnmarshalZCash(e.MarshalZCash(Q, true)); err == nil {
+				t.Fatalf("%v: accepted point not in the subgroup: %v\n", id, Q)
+			}
+		}
+		for _, b := range [][]byte{
+			g[:len(g)-1],                                    // wrong length
+			append([]byte{0x20}, g[1:]...),                  // uncompressed with sign
+			append([]byte{0xe0}, make([]byte, len(g)-1)...), // infinity with sign
+			append([]byte{0xc0}, g[1:]...),                  // infinity with non-zero x
+		} {
+			if _, err := e.UnmarshalZCash(b); err == nil {
+				t.Fatalf("%v: accepted invalid encoding %x\n", id, b)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	F := GF.NewFp(0, 59)
+	_, errSingular := C.NewWeierstrassE(0, F, F.Zero(), F.Zero(), big.NewInt(1), big.NewInt(1))
+	E := C.P256.Get()
+	_, errPoint := E.NewPointE(E.Field().Zero(), E.Field().Zero())
+	_, errID := C.CurveID(255).TryGet()
+	_, errZCash := C.P256.Get().(*C.WECurve).UnmarshalZCash([]byte{0xc0})
+	for _, v := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"NewWeierstrassE", errSingular, C.ErrInvalidCurve},
+		{"NewPointE", errPoint, C.ErrNotOnCurve},
+		{"TryGet", errID, C.ErrUnsupported},
+		{"UnmarshalZCash", errZCash, C.ErrUnsupported},
+	} {
+		if !errors.Is(v.err, v.want) {
+			t.Fatalf("%v: got: %v want: %v", v.name, v.err, v.want)
+		}
+	}
+}
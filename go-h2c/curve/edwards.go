@@ -1,7 +1,6 @@
 package curve
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -17,22 +16,40 @@ func (e *TECurve) String() string {
 	return fmt.Sprintf("Ax^2+y^2=1+Dx^2y^2\nF: %v\nA: %v\nD: %v\n", e.F, e.A, e.D)
 }
 
-// NewEdwards returns a twisted Edwards curve
+// NewEdwards returns a twisted Edwards curve, it panics if the parameters are
+// invalid.
 func NewEdwards(id CurveID, f GF.Field, a, d GF.Elt, r, h *big.Int) *TECurve {
+	e, err := NewEdwardsE(id, f, a, d, r, h)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// NewEdwardsE returns a twisted Edwards curve, or ErrInvalidCurve if the
+// parameters are invalid.
+func NewEdwardsE(id CurveID, f GF.Field, a, d GF.Elt, r, h *big.Int) (*TECurve, error) {
 	if e := (&TECurve{&params{
 		Id: id, F: f, A: a, D: d, R: r, H: h,
 	}}); e.IsValid() {
-		return e
+		return e, nil
 	}
-	panic(errors.New("can't instantiate a twisted Edwards curve"))
+	return nil, ErrInvalidCurve
 }
 
-func (e *TECurve) NewPoint(x, y GF.Elt) (P Point) {
-	if P = (&ptTe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
-		return P
+// NewPoint returns the point (x,y), it panics if the point is not on the
+// curve.
+func (e *TECurve) NewPoint(x, y GF.Elt) Point { return mustPoint(e.NewPointE(x, y)) }
+
+// NewPointE returns the point (x,y), or ErrNotOnCurve if the point is not
+// on the curve.
+func (e *TECurve) NewPointE(x, y GF.Elt) (Point, error) {
+	if P := (&ptTe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+		return P, nil
 	}
-	panic(fmt.Errorf("p:%v not on %v", P, e))
+	return nil, fmt.Errorf("%w: (%v, %v)", ErrNotOnCurve, x, y)
 }
+
 func (e *TECurve) IsValid() bool {
 	F := e.F
 	cond1 := !F.AreEqual(e.A, e.D) // A != D
@@ -44,9 +61,12 @@ func (e *TECurve) IsEqual(ec EllCurve) bool {
 	e0 := ec.(*TECurve)
 	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.D, e0.D)
 }
+
+// IsComplete returns true if A is a square and D is not a square, so the
+// unified formulas of addExt are complete.
 func (e *TECurve) IsComplete() bool {
 	F := e.F
-	return F.IsSquare(e.A) && !F.IsSquare(e.D) // A != D
+	return F.IsSquare(e.A) && !F.IsSquare(e.D)
 }
 func (e *TECurve) IsOnCurve(p Point) bool {
 	P := p.(*ptTe)
@@ -63,6 +83,16 @@ func (e *TECurve) IsOnCurve(p Point) bool {
 }
 func (e *TECurve) Identity() Point { return e.NewPoint(e.F.Zero(), e.F.One()) }
 func (e *TECurve) Add(p, q Point) Point {
+	if !e.IsComplete() {
+		return e.addAffine(p, q)
+	}
+	return e.toAffine(e.addExt(e.toExtended(p), e.toExtended(q)))
+}
+
+// addAffine adds points using the affine unified formulas, which are used on
+// curves that are not complete. It panics if a denominator is zero, which
+// happens only if the sum is not an affine point.
+func (e *TECurve) addAffine(p, q Point) Point {
 	P := p.(*ptTe)
 	Q := q.(*ptTe)
 	F := e.F
@@ -74,8 +104,11 @@ func (e *TECurve) Add(p, q Point) Point {
 	t0 = F.Mul(t0, Q.y)     // Dx1y1x2y2
 	t2 = F.Add(F.One(), t0) // 1+Dx1y1x2y2
 	t3 = F.Sub(F.One(), t0) // 1-Dx1y1x2y2
-	t2 = F.Inv(t2)          // 1/(1+Dx1y1x2y2)
-	t3 = F.Inv(t3)          // 1/(1-Dx1y1x2y2)
+	if F.IsZero(t2) || F.IsZero(t3) {
+		panic("wrong inputs")
+	}
+	t2 = F.Inv(t2) // 1/(1+Dx1y1x2y2)
+	t3 = F.Inv(t3) // 1/(1-Dx1y1x2y2)
 
 	t0 = F.Mul(P.x, Q.y) // x1y2
 	t1 = F.Mul(Q.x, P.y) // x2y1
@@ -94,18 +127,76 @@ func (e *TECurve) Neg(p Point) Point {
 	P := p.(*ptTe)
 	return &ptTe{e, &afPoint{x: e.F.Neg(P.x), y: P.y.Copy()}}
 }
-func (e *TECurve) Double(p Point) Point { return e.Add(p, p) }
+func (e *TECurve) Double(p Point) Point {
+	if !e.IsComplete() {
+		return e.addAffine(p, p)
+	}
+	return e.toAffine(e.doubleExt(e.toExtended(p)))
+}
+
+// ScalarMult returns [k]P using a signed fixed window, the unified formulas,
+// and table lookups that do not depend on k, so that the sequence of
+// operations only depends on the bit length of r, or of k if it is larger.
+// The formulas are complete only if the curve is complete, so on other curves
+// ScalarMult uses the double-and-add method with the affine formulas, which is
+// not constant time.
 func (e *TECurve) ScalarMult(p Point, k *big.Int) Point {
-	Q := e.Identity()
+	if !e.IsComplete() {
+		Q := e.scalarMultBinary(p, new(big.Int).Abs(k))
+		if k.Sign() < 0 {
+			Q = e.Neg(Q)
+		}
+		return Q
+	}
+	P := e.toExtended(p)
+	kk, even, neg := oddScalar(k)
+	d := recodeScalar(kk, max(kk.BitLen(), e.R.BitLen()))
+	T := e.tableExt(P)
+	Q := e.lookupExt(T, d[len(d)-1])
+	for i := len(d) - 2; i >= 0; i-- {
+		for j := 0; j < windowWidth; j++ {
+			Q = e.doubleExt(Q)
+		}
+		Q = e.addExt(Q, e.lookupExt(T, d[i]))
+	}
+	Q = e.cmovExt(Q, e.addExt(Q, e.cnegExt(P, true)), even)
+	return e.toAffine(e.cnegExt(Q, neg))
+}
+
+// scalarMultBinary returns [k]P using the double-and-add method with the
+// unified formulas. It is not constant time, so it is only used for public
+// scalars, and on curves that are not complete.
+func (e *TECurve) scalarMultBinary(p Point, k *big.Int) Point {
+	if !e.IsComplete() {
+		Q := e.Identity()
+		for i := k.BitLen() - 1; i >= 0; i-- {
+			Q = e.addAffine(Q, Q)
+			if k.Bit(i) != 0 {
+				Q = e.addAffine(Q, p)
+			}
+		}
+		return Q
+	}
+	P := e.toExtended(p)
+	Q := e.toExtended(e.Identity())
 	for i := k.BitLen() - 1; i >= 0; i-- {
-		Q = e.Double(Q)
+		Q = e.doubleExt(Q)
 		if k.Bit(i) != 0 {
-			Q = e.Add(Q, p)
+			Q = e.addExt(Q, P)
 		}
 	}
-	return Q
+	return e.toAffine(Q)
 }
-func (e *TECurve) ClearCofactor(p Point) Point { return e.ScalarMult(p, e.H) }
+func (e *TECurve) ClearCofactor(p Point) Point {
+	if e.clear != nil {
+		return e.clear(e, p)
+	}
+	return e.scalarMultBinary(p, e.H)
+}
+
+// IsInSubgroup returns true if [r]P is the identity, where r is the order of
+// the prime-order subgroup.
+func (e *TECurve) IsInSubgroup(p Point) bool { return e.scalarMultBinary(p, e.R).IsIdentity() }
 
 type ptTe struct {
 	*TECurve
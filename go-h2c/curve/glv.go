@@ -0,0 +1,106 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// GLV holds the parameters of the method of Gallant, Lambert and Vanstone
+// for curves y^2=x^3+B, whose endomorphism phi(x, y) = (Beta*x, y) acts as
+// the multiplication by Lambda on the subgroup of prime order r. The vectors
+// (A1, B1) and (A2, B2) are a short basis of the lattice of pairs (a, b) such
+// that a + b*Lambda = 0 mod r, which is used to split scalars into two halves
+// (Guide to Elliptic Curve Cryptography, Section 3.5).
+type GLV struct {
+	Beta           GF.Elt
+	Lambda         *big.Int
+	A1, B1, A2, B2 *big.Int
+}
+
+// SetGLV registers the parameters g, so that ScalarMult uses the endomorphism
+// phi. If g is nil, ScalarMult uses a signed fixed window. It returns
+// ErrInvalidCurve if A is not zero or the parameters are inconsistent. Since
+// phi acts as the multiplication by Lambda only on the subgroup of prime
+// order, ScalarMult uses it only for points that pass IsInSubgroup, such as
+// those returned by ClearCofactor; other points of curves with a cofactor, for
+// instance BLS12381G1, use the signed fixed window.
+func (e *WECurve) SetGLV(g *GLV) error {
+	if g == nil {
+		e.glv = nil
+		return nil
+	}
+	F := e.F
+	r := e.R
+	one := big.NewInt(1)
+	isZero := func(a, b *big.Int) bool {
+		t := new(big.Int).Mul(b, g.Lambda)
+		return t.Add(t, a).Mod(t, r).Sign() == 0
+	}
+	switch {
+	case !F.IsZero(e.A),
+		!F.AreEqual(F.Exp(g.Beta, big.NewInt(3)), F.One()) || F.AreEqual(g.Beta, F.One()),
+		new(big.Int).Exp(g.Lambda, big.NewInt(3), r).Cmp(one) != 0 || g.Lambda.Cmp(one) == 0,
+		!isZero(g.A1, g.B1) || !isZero(g.A2, g.B2):
+		return fmt.Errorf("%w: invalid GLV parameters", ErrInvalidCurve)
+	}
+	e.glv = g
+	return nil
+}
+
+// split returns k1 and k2 such that k = k1 + k2*lambda mod r, where k1 and
+// k2 have about half the bit length of r (Guide to Elliptic Curve
+// Cryptography, Algorithm 3.74).
+func (g *GLV) split(k, r *big.Int) (k1, k2 *big.Int) {
+	// round returns the closest integer to a/r.
+	twoR := new(big.Int).Lsh(r, 1)
+	round := func(a *big.Int) *big.Int {
+		t := new(big.Int).Lsh(a, 1)
+		t.Add(t, r)
+		return t.Div(t, twoR)
+	}
+	k = new(big.Int).Mod(k, r)
+	c1 := round(new(big.Int).Mul(g.B2, k))
+	c2 := round(new(big.Int).Neg(new(big.Int).Mul(g.B1, k)))
+
+	k1 = new(big.Int).Set(k)
+	k1.Sub(k1, new(big.Int).Mul(c1, g.A1))
+	k1.Sub(k1, new(big.Int).Mul(c2, g.A2))
+	k2 = new(big.Int).Mul(c1, g.B1)
+	k2.Add(k2, new(big.Int).Mul(c2, g.B2))
+	k2.Neg(k2)
+	return k1, k2
+}
+
+// scalarMultGLV computes [k]P as [k1]P + [k2]phi(P) using signed fixed
+// windows for both scalars, which requires P in the subgroup of prime order.
+func (e *WECurve) scalarMultGLV(p Point, k *big.Int) Point {
+	F := e.F
+	k1, k2 := e.glv.split(k, e.R)
+	k1, even1, neg1 := oddScalar(k1)
+	k2, even2, neg2 := oddScalar(k2)
+	l := max(k1.BitLen(), k2.BitLen(), (e.R.BitLen()+1)/2+1)
+	d1 := recodeScalar(k1, l)
+	d2 := recodeScalar(k2, l)
+
+	// T2 holds the odd multiples of phi(P), with the sign of k2.
+	T1 := e.tablePrj(e.cnegPrj(e.toProjective(p), neg1))
+	T2 := make([]*prjPoint, len(T1))
+	for i, P := range T1 {
+		T2[i] = e.cnegPrj(&prjPoint{F.Mul(e.glv.Beta, P.x), P.y, P.z}, neg1 != neg2)
+	}
+
+	m := len(d1) - 1
+	Q := e.addPrj(e.lookupPrj(T1, d1[m]), e.lookupPrj(T2, d2[m]))
+	for i := m - 1; i >= 0; i-- {
+		for j := 0; j < windowWidth; j++ {
+			Q = e.doublePrj(Q)
+		}
+		Q = e.addPrj(Q, e.lookupPrj(T1, d1[i]))
+		Q = e.addPrj(Q, e.lookupPrj(T2, d2[i]))
+	}
+	Q = e.cmovPrj(Q, e.addPrj(Q, e.cnegPrj(T1[0], true)), even1)
+	Q = e.cmovPrj(Q, e.addPrj(Q, e.cnegPrj(T2[0], true)), even2)
+	return e.toAffine(Q)
+}
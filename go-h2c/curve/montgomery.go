@@ -1,7 +1,6 @@
 package curve
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -15,22 +14,40 @@ type M = *MTCurve
 
 func (e *MTCurve) String() string { return "By^2=x^3+Ax^2+x\n" + e.params.String() }
 
-// NewMontgomery returns a Montgomery curve
+// NewMontgomery returns a Montgomery curve, it panics if the parameters are
+// invalid.
 func NewMontgomery(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) *MTCurve {
+	e, err := NewMontgomeryE(id, f, a, b, r, h)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// NewMontgomeryE returns a Montgomery curve, or ErrInvalidCurve if the
+// parameters are invalid.
+func NewMontgomeryE(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) (*MTCurve, error) {
 	if e := (&MTCurve{&params{
 		Id: id, F: f, A: a, B: b, R: r, H: h,
 	}}); e.IsValid() {
-		return e
+		return e, nil
 	}
-	panic(errors.New("can't instantiate a Montgomery curve"))
+	return nil, ErrInvalidCurve
 }
 
-func (e *MTCurve) NewPoint(x, y GF.Elt) (P Point) {
-	if P = (&ptMt{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
-		return P
+// NewPoint returns the point (x,y), it panics if the point is not on the
+// curve.
+func (e *MTCurve) NewPoint(x, y GF.Elt) Point { return mustPoint(e.NewPointE(x, y)) }
+
+// NewPointE returns the point (x,y), or ErrNotOnCurve if the point is not
+// on the curve.
+func (e *MTCurve) NewPointE(x, y GF.Elt) (Point, error) {
+	if P := (&ptMt{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+		return P, nil
 	}
-	panic(fmt.Errorf("p:%v not on %v", P, e))
+	return nil, fmt.Errorf("%w: (%v, %v)", ErrNotOnCurve, x, y)
 }
+
 func (e *MTCurve) IsValid() bool {
 	F := e.F
 	t0 := F.Sqr(e.A)         // A^2
@@ -139,17 +156,27 @@ func (e *MTCurve) Double(p Point) Point {
 	return &ptMt{e, &afPoint{x: x, y: y}}
 }
 
+// ScalarMult returns [k]P using the Montgomery ladder, see scalarMultLadder.
 func (e *MTCurve) ScalarMult(p Point, k *big.Int) Point {
-	Q := e.Identity()
-	for i := k.BitLen() - 1; i >= 0; i-- {
-		Q = e.Double(Q)
-		if k.Bit(i) != 0 {
-			Q = e.Add(Q, p)
-		}
+	kk := new(big.Int).Abs(k)
+	Q := e.scalarMultLadder(p, kk, max(kk.BitLen(), e.R.BitLen()))
+	if k.Sign() < 0 {
+		Q = e.Neg(Q)
 	}
 	return Q
 }
-func (e *MTCurve) ClearCofactor(p Point) Point { return e.ScalarMult(p, e.H) }
+func (e *MTCurve) ClearCofactor(p Point) Point {
+	if e.clear != nil {
+		return e.clear(e, p)
+	}
+	return e.scalarMultLadder(p, e.H, e.H.BitLen())
+}
+
+// IsInSubgroup returns true if [r]P is the identity, where r is the order of
+// the prime-order subgroup.
+func (e *MTCurve) IsInSubgroup(p Point) bool {
+	return e.scalarMultLadder(p, e.R, e.R.BitLen()).IsIdentity()
+}
 
 // ptMt is an affine point on a Montgomery curve.
 type ptMt struct {
@@ -160,8 +187,8 @@ type ptMt struct {
 func (p *ptMt) String() string { return p.afPoint.String() }
 func (p *ptMt) Copy() Point    { return &ptMt{p.MTCurve, p.copy()} }
 func (p *ptMt) IsEqual(q Point) bool {
-	qq := q.(*ptMt)
-	return p.MTCurve.IsEqual(qq.MTCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptMt)
+	return ok && p.MTCurve.IsEqual(qq.MTCurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptMt) IsIdentity() bool   { return false }
 func (p *ptMt) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
@@ -0,0 +1,106 @@
+package curve
+
+import (
+	"math/big"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// ScalarMultX returns the x-coordinate of [k]P, where x is the x-coordinate
+// of P, using the Montgomery ladder in XZ coordinates (RFC 7748, Section 5).
+// Since B is not involved, x can also be the x-coordinate of a point on the
+// quadratic twist. It returns 0 if [k]P is the point at infinity.
+func (e *MTCurve) ScalarMultX(x GF.Elt, k *big.Int) GF.Elt {
+	F := e.F
+	x2, z2, _, _ := e.ladder(x, k, max(k.BitLen(), e.R.BitLen()))
+	return F.Mul(x2, F.Inv0(z2))
+}
+
+// ladder returns (X2:Z2) and (X3:Z3), the XZ coordinates of [k]P and
+// [k+1]P, where x is the x-coordinate of P. It uses conditional swaps and
+// processes the n least significant bits of k, where n >= k.BitLen(). For
+// secret scalars, n is the bit length of r, or of k if it is larger, so that
+// the sequence of operations does not depend on the value of k; for public
+// scalars, n is the bit length of k.
+func (e *MTCurve) ladder(x GF.Elt, k *big.Int, n int) (x2, z2, x3, z3 GF.Elt) {
+	F := e.F
+	a24 := F.Sub(e.A, F.Elt(2))       // A-2
+	a24 = F.Mul(a24, F.Inv(F.Elt(4))) // (A-2)/4
+	x1 := x
+	x2, z2 = F.One(), F.Zero()
+	x3, z3 = x.Copy(), F.One()
+	swap := false
+	for t := n - 1; t >= 0; t-- {
+		kt := k.Bit(t) == 1
+		swap = swap != kt
+		x2, x3 = F.CMov(x2, x3, swap), F.CMov(x3, x2, swap)
+		z2, z3 = F.CMov(z2, z3, swap), F.CMov(z3, z2, swap)
+		swap = kt
+
+		a := F.Add(x2, z2)                        // A = x_2 + z_2
+		aa := F.Sqr(a)                            // AA = A^2
+		b := F.Sub(x2, z2)                        // B = x_2 - z_2
+		bb := F.Sqr(b)                            // BB = B^2
+		ee := F.Sub(aa, bb)                       // E = AA - BB
+		c := F.Add(x3, z3)                        // C = x_3 + z_3
+		d := F.Sub(x3, z3)                        // D = x_3 - z_3
+		da := F.Mul(d, a)                         // DA = D * A
+		cb := F.Mul(c, b)                         // CB = C * B
+		x3 = F.Sqr(F.Add(da, cb))                 // x_3 = (DA + CB)^2
+		z3 = F.Mul(x1, F.Sqr(F.Sub(da, cb)))      // z_3 = x_1 * (DA - CB)^2
+		x2 = F.Mul(aa, bb)                        // x_2 = AA * BB
+		z2 = F.Mul(ee, F.Add(aa, F.Mul(a24, ee))) // z_2 = E * (AA + a24 * E)
+	}
+	x2, x3 = F.CMov(x2, x3, swap), F.CMov(x3, x2, swap)
+	z2, z3 = F.CMov(z2, z3, swap), F.CMov(z3, z2, swap)
+	return
+}
+
+// scalarMultLadder returns [k]P computed with the Montgomery ladder, then the
+// y-coordinate is recovered with the method of Okeya and Sakurai as in
+// https://eprint.iacr.org/2017/212 (Algorithm 5). Only points of order two
+// and the exceptional outputs [k]P = O and [k+1]P = O are handled apart. The
+// ladder processes n bits of k, see ladder.
+func (e *MTCurve) scalarMultLadder(p Point, k *big.Int, n int) Point {
+	if p.IsIdentity() {
+		return e.Identity()
+	}
+	P := p.(*ptMt)
+	F := e.F
+	if P.IsTwoTorsion() {
+		if k.Bit(0) == 0 {
+			return e.Identity()
+		}
+		return P.Copy()
+	}
+	x1, z1, x2, z2 := e.ladder(P.x, k, n)
+	if F.IsZero(z1) {
+		return e.Identity()
+	} else if F.IsZero(z2) {
+		return e.Neg(P)
+	}
+	var v1, v2, v3, v4 GF.Elt
+	v1 = F.Mul(P.x, z1)  // 1.  v1 = xP Z1
+	v2 = F.Add(x1, v1)   // 2.  v2 = X1 + v1
+	v3 = F.Sub(x1, v1)   // 3.  v3 = X1 - v1
+	v3 = F.Sqr(v3)       // 4.  v3 = v3^2
+	v3 = F.Mul(v3, x2)   // 5.  v3 = v3 X2
+	v1 = F.Add(e.A, e.A) // 6.  v1 = 2A
+	v1 = F.Mul(v1, z1)   //     v1 = 2A Z1
+	v2 = F.Add(v2, v1)   // 7.  v2 = v2 + v1
+	v4 = F.Mul(P.x, x1)  // 8.  v4 = xP X1
+	v4 = F.Add(v4, z1)   // 9.  v4 = v4 + Z1
+	v2 = F.Mul(v2, v4)   // 10. v2 = v2 v4
+	v1 = F.Mul(v1, z1)   // 11. v1 = v1 Z1
+	v2 = F.Sub(v2, v1)   // 12. v2 = v2 - v1
+	v2 = F.Mul(v2, z2)   // 13. v2 = v2 Z2
+	y := F.Sub(v2, v3)   // 14.  Y = v2 - v3
+	v1 = F.Add(e.B, e.B) // 15. v1 = 2B
+	v1 = F.Mul(v1, P.y)  //     v1 = 2B yP
+	v1 = F.Mul(v1, z1)   // 16. v1 = v1 Z1
+	v1 = F.Mul(v1, z2)   // 17. v1 = v1 Z2
+	x := F.Mul(v1, x1)   // 18.  X = v1 X1
+	z := F.Mul(v1, z1)   // 19.  Z = v1 Z1
+	invZ := F.Inv(z)
+	return &ptMt{e, &afPoint{x: F.Mul(x, invZ), y: F.Mul(y, invZ)}}
+}
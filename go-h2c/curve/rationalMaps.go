@@ -77,8 +77,8 @@ func (r *te2wec) Push(p Point) Point {
 	t1 = F.Mul(t1, r.invSqrtD) // invSqrtD*(1-y)
 	t1 = F.Inv(t1)             // 1/(invSqrtD*(1-y))
 	x := F.Mul(t0, t1)         // x = (1+y)/(invSqrtD*(1-y))
-	t0 = F.Inv(P.y)            // 1/y
-	y := F.Mul(x, t0)          // y = x/y
+	t0 = F.Inv(P.x)            // 1/X
+	y := F.Mul(x, t0)          // y = x/X
 	return r.E1.NewPoint(x, y)
 }
 func (r *te2wec) Pull(p Point) Point {
@@ -152,15 +152,19 @@ type te2mt25519 struct {
 }
 
 // FromTe2Mt25519 returns the birational map between Edwards25519 and Curve25519 curves.
-func FromTe2Mt25519() RationalMap {
-	e0 := Edwards25519.Get()
-	e1 := Curve25519.Get()
+func FromTe2Mt25519() RationalMap { return mustMap(FromTe2Mt(Edwards25519.Get())) }
+
+func newTe2Mt25519(e0 EllCurve) (RationalMap, error) {
+	e1, err := Curve25519.over(e0.Field())
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return te2mt25519{
 		E0:       e0.(T),
 		E1:       e1.(M),
 		invSqrtD: F.Elt("6853475219497561581579357271197624642482790079785650197046958215289687604742"),
-	}
+	}, nil
 }
 func (m te2mt25519) String() string     { return fmt.Sprintf("Rational Map from %v to\n%v", m.E0, m.E1) }
 func (m te2mt25519) Domain() EllCurve   { return m.E0 }
@@ -205,7 +209,39 @@ type te2mt4iso448 struct {
 }
 
 // FromTe2Mt4ISO448 returns the four-degree isogeny between Edwards448 and Curve448 curves.
-func FromTe2Mt4ISO448() RationalMap       { return te2mt4iso448{Edwards448.Get().(T), Curve448.Get().(M)} }
+func FromTe2Mt4ISO448() RationalMap { return mustMap(FromTe2Mt(Edwards448.Get())) }
+
+func newTe2Mt4ISO448(e0 EllCurve) (RationalMap, error) {
+	e1, err := Curve448.over(e0.Field())
+	if err != nil {
+		return nil, err
+	}
+	return te2mt4iso448{e0.(T), e1.(M)}, nil
+}
+
+// FromTe2Mt returns the map from the Edwards25519 or Edwards448 curve e to
+// Curve25519 or Curve448, respectively, see FromTe2Mt25519 and
+// FromTe2Mt4ISO448. Both curves are defined over the field of e, so the map
+// keeps the backend of the field. It returns ErrUnsupported for other curves.
+func FromTe2Mt(e EllCurve) (RationalMap, error) {
+	if te, ok := e.(T); ok {
+		switch te.Id {
+		case Edwards25519:
+			return newTe2Mt25519(e)
+		case Edwards448:
+			return newTe2Mt4ISO448(e)
+		}
+	}
+	return nil, fmt.Errorf("%w: no map to a Montgomery curve for %v", ErrUnsupported, e)
+}
+
+func mustMap(r RationalMap, err error) RationalMap {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
 func (m te2mt4iso448) String() string     { return fmt.Sprintf("4-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m te2mt4iso448) Domain() EllCurve   { return m.E0 }
 func (m te2mt4iso448) Codomain() EllCurve { return m.E1 }
@@ -261,15 +297,45 @@ func (m te2mt4iso448) Pull(p Point) Point {
 	return m.E0.NewPoint(xx, yy)
 }
 
+// IsogenyTo returns the isogeny used in RFC 9380 to hash to the SECP256K1,
+// BLS12381G1 or BLS12381G2 curve e, see GetSECP256K1Isogeny,
+// GetBLS12381G1Isogeny and GetBLS12381G2Isogeny. Both curves are defined over
+// the field of e, so the isogeny keeps the backend of the field. It returns
+// ErrUnsupported for other curves.
+func IsogenyTo(e EllCurve) (Isogeny, error) {
+	if we, ok := e.(W); ok {
+		switch we.Id {
+		case SECP256K1:
+			return newSECP256K1Isogeny(e)
+		case BLS12381G1:
+			return newBLS12381G1Isogeny(e)
+		case BLS12381G2:
+			return newBLS12381G2Isogeny(e)
+		}
+	}
+	return nil, fmt.Errorf("%w: no isogeny to %v", ErrUnsupported, e)
+}
+
+func mustIsogeny(i Isogeny, err error) Isogeny {
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
 type isosecp256k1 struct {
 	E0, E1                 W
 	xNum, xDen, yNum, yDen []GF.Elt
 }
 
 // GetSECP256K1Isogeny returns a 3-degree isogeny from SECP256K1_3ISO to the SECP256K1 elliptic curve.
-func GetSECP256K1Isogeny() Isogeny {
-	e0 := SECP256K1_3ISO.Get()
-	e1 := SECP256K1.Get()
+func GetSECP256K1Isogeny() Isogeny { return mustIsogeny(IsogenyTo(SECP256K1.Get())) }
+
+func newSECP256K1Isogeny(e1 EllCurve) (Isogeny, error) {
+	e0, err := SECP256K1_3ISO.over(e1.Field())
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return isosecp256k1{
 		E0: e0.(W),
@@ -294,7 +360,7 @@ func GetSECP256K1Isogeny() Isogeny {
 			F.Elt("0x7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
 			F.Elt("0x6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
 			F.One()},
-	}
+	}, nil
 }
 func (m isosecp256k1) String() string     { return fmt.Sprintf("3-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m isosecp256k1) Domain() EllCurve   { return m.E0 }
@@ -320,10 +386,14 @@ type isobls12381G1 struct {
 	xNum, xDen, yNum, yDen []GF.Elt
 }
 
-// GetSECP256K1Isogeny returns an 11-degree isogeny from BLS12381G1_11ISO to the BLS12381G1 elliptic curve.
-func GetBLS12381G1Isogeny() Isogeny {
-	e0 := BLS12381G1_11ISO.Get()
-	e1 := BLS12381G1.Get()
+// GetBLS12381G1Isogeny returns an 11-degree isogeny from BLS12381G1_11ISO to the BLS12381G1 elliptic curve.
+func GetBLS12381G1Isogeny() Isogeny { return mustIsogeny(IsogenyTo(BLS12381G1.Get())) }
+
+func newBLS12381G1Isogeny(e1 EllCurve) (Isogeny, error) {
+	e0, err := BLS12381G1_11ISO.over(e1.Field())
+	if err != nil {
+		return nil, err
+	}
 	F := e0.Field()
 	return isobls12381G1{
 		E0: e0.(W),
@@ -388,7 +458,7 @@ func GetBLS12381G1Isogeny() Isogeny {
 			F.Elt("0x2660400eb2e4f3b628bdd0d53cd76f2bf565b94e72927c1cb748df27942480e420517bd8714cc80d1fadc1326ed06f7"),
 			F.Elt("0xe0fa1d816ddc03e6b24255e0d7819c171c40f65e273b853324efcd6356caa205ca2f570f13497804415473a1d634b8f"),
 			F.One()},
-	}
+	}, nil
 }
 func (m isobls12381G1) String() string     { return fmt.Sprintf("11-Isogeny from %v to\n%v", m.E0, m.E1) }
 func (m isobls12381G1) Domain() EllCurve   { return m.E0 }
@@ -411,3 +481,87 @@ func (m isobls12381G1) Push(p Point) Point {
 	yy = F.Mul(yy, y)
 	return m.E1.NewPoint(xx, yy)
 }
+
+type isobls12381G2 struct {
+	E0, E1                 W
+	xNum, xDen, yNum, yDen []GF.Elt
+}
+
+// GetBLS12381G2Isogeny returns a 3-degree isogeny from BLS12381G2_3ISO to the BLS12381G2 elliptic curve.
+func GetBLS12381G2Isogeny() Isogeny { return mustIsogeny(IsogenyTo(BLS12381G2.Get())) }
+
+func newBLS12381G2Isogeny(e1 EllCurve) (Isogeny, error) {
+	e0, err := BLS12381G2_3ISO.over(e1.Field())
+	if err != nil {
+		return nil, err
+	}
+	F := e0.Field()
+	return isobls12381G2{
+		E0: e0.(W),
+		E1: e1.(W),
+		xNum: []GF.Elt{
+			F.Elt([]interface{}{
+				"0x5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+				"0x5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6"}),
+			F.Elt([]interface{}{
+				"0x0",
+				"0x11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71a"}),
+			F.Elt([]interface{}{
+				"0x11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71e",
+				"0x8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38d"}),
+			F.Elt([]interface{}{
+				"0x171d6541fa38ccfaed6dea691f5fb614cb14b4e7f4e810aa22d6108f142b85757098e38d0f671c7188e2aaaaaaaa5ed1",
+				"0x0"})},
+		xDen: []GF.Elt{
+			F.Elt([]interface{}{
+				"0x0",
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa63"}),
+			F.Elt([]interface{}{
+				"0xc",
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa9f"}),
+			F.One(),
+			F.Zero()},
+		yNum: []GF.Elt{
+			F.Elt([]interface{}{
+				"0x1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+				"0x1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706"}),
+			F.Elt([]interface{}{
+				"0x0",
+				"0x5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97be"}),
+			F.Elt([]interface{}{
+				"0x11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71c",
+				"0x8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38f"}),
+			F.Elt([]interface{}{
+				"0x124c9ad43b6cf79bfbf7043de3811ad0761b0f37a1e26286b0e977c69aa274524e79097a56dc4bd9e1b371c71c718b10",
+				"0x0"})},
+		yDen: []GF.Elt{
+			F.Elt([]interface{}{
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb"}),
+			F.Elt([]interface{}{
+				"0x0",
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa9d3"}),
+			F.Elt([]interface{}{
+				"0x12",
+				"0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa99"}),
+			F.One()},
+	}, nil
+}
+func (m isobls12381G2) String() string     { return fmt.Sprintf("3-Isogeny from %v to\n%v", m.E0, m.E1) }
+func (m isobls12381G2) Domain() EllCurve   { return m.E0 }
+func (m isobls12381G2) Codomain() EllCurve { return m.E1 }
+func (m isobls12381G2) Push(p Point) Point {
+	F := m.E0.F
+	x, y := p.X(), p.Y()
+	xNum, xDen, yNum, yDen := F.Zero(), F.Zero(), F.Zero(), F.Zero()
+	for i := 3; i >= 0; i-- {
+		xNum = F.Add(F.Mul(xNum, x), m.xNum[i])
+		xDen = F.Add(F.Mul(xDen, x), m.xDen[i])
+		yNum = F.Add(F.Mul(yNum, x), m.yNum[i])
+		yDen = F.Add(F.Mul(yDen, x), m.yDen[i])
+	}
+	xx := F.Mul(xNum, F.Inv(xDen))
+	yy := F.Mul(yNum, F.Inv(yDen))
+	yy = F.Mul(yy, y)
+	return m.E1.NewPoint(xx, yy)
+}
@@ -0,0 +1,62 @@
+package curve
+
+import (
+	"fmt"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// encodingSize returns the length in bytes of the encoding of a u-coordinate.
+func (e *MTCurve) encodingSize() int { return (e.F.P().BitLen() + 7) / 8 }
+
+// MarshalX returns the encoding of the u-coordinate x in little-endian order
+// as in RFC 7748 (Section 5).
+func (e *MTCurve) MarshalX(x GF.Elt) []byte {
+	return bigToLE(e.F.Coeffs(x)[0], e.encodingSize())
+}
+
+// UnmarshalX decodes a u-coordinate as in RFC 7748 (Section 5), that is, the
+// unused most significant bits are masked and non-canonical values are
+// reduced modulo p. The u-coordinate can be on the curve or on its twist. It
+// returns ErrInvalidEncoding if b has not the length of an encoding.
+func (e *MTCurve) UnmarshalX(b []byte) (GF.Elt, error) {
+	n := e.encodingSize()
+	if len(b) != n {
+		return nil, fmt.Errorf("%w: length %v", ErrInvalidEncoding, len(b))
+	}
+	c := append([]byte(nil), b...)
+	if bits := e.F.P().BitLen() % 8; bits != 0 {
+		c[n-1] &= (1 << uint(bits)) - 1
+	}
+	return e.F.Elt(leToBig(c)), nil
+}
+
+// Marshal returns the encoding of the u-coordinate of P, where the point at
+// infinity has u = 0 as in the outputs of X25519 and X448.
+func (e *MTCurve) Marshal(p Point) []byte {
+	if p.IsIdentity() {
+		return e.MarshalX(e.F.Zero())
+	}
+	return e.MarshalX(p.X())
+}
+
+// Unmarshal decodes a u-coordinate and returns the point with that
+// u-coordinate and even v-coordinate, since the encoding does not hold the
+// sign of v; hence, u = 0 decodes to (0, 0) rather than to the point at
+// infinity. It returns an error if the u-coordinate is on the twist.
+func (e *MTCurve) Unmarshal(b []byte) (Point, error) {
+	F := e.F
+	x, err := e.UnmarshalX(b)
+	if err != nil {
+		return nil, err
+	}
+	t0 := F.Add(F.Sqr(x), F.Mul(e.A, x)) // x^2 + Ax
+	t0 = F.Mul(F.Add(t0, F.One()), x)    // x^3 + Ax^2 + x
+	y2 := F.Mul(t0, F.Inv(e.B))          // y^2 = (x^3 + Ax^2 + x)/B
+	if !F.IsSquare(y2) {
+		return nil, ErrNotOnCurve
+	}
+	y := F.Sqrt(y2)
+	y = F.CMov(y, F.Neg(y), F.GetSgn0(GF.SignLE)(y) == -1)
+	return e.NewPoint(x, y), nil
+}
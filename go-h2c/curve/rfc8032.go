@@ -0,0 +1,80 @@
+package curve
+
+import (
+	"math/big"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// leToBig returns the integer encoded in little-endian order by b.
+func leToBig(b []byte) *big.Int {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(r)
+}
+
+// bigToLE encodes n in little-endian order using size bytes.
+func bigToLE(n *big.Int, size int) []byte {
+	b := n.FillBytes(make([]byte, size))
+	for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+// encodingSize returns the length in bytes of the encoding of a point, which
+// holds the y-coordinate and one bit for the sign of the x-coordinate.
+func (e *TECurve) encodingSize() int { return (e.F.P().BitLen() + 1 + 7) / 8 }
+
+// Marshal returns the encoding of P as in RFC 8032 (Sections 5.1.2 and
+// 5.2.2): the y-coordinate in little-endian order, where the most significant
+// bit of the last byte is the least significant bit of the x-coordinate.
+func (e *TECurve) Marshal(p Point) []byte {
+	P := p.(*ptTe)
+	n := e.encodingSize()
+	y := e.F.Coeffs(P.y)[0]
+	b := bigToLE(y, n)
+	if e.F.GetSgn0(GF.SignLE)(P.x) == -1 {
+		b[n-1] |= 0x80
+	}
+	return b
+}
+
+// Unmarshal decodes a point as in RFC 8032 (Sections 5.1.3 and 5.2.3). It
+// returns an error if the y-coordinate is not reduced modulo p, or there is no
+// point with the encoded coordinates. The point is not checked to be in the
+// subgroup of prime order.
+func (e *TECurve) Unmarshal(b []byte) (Point, error) {
+	F := e.F
+	n := e.encodingSize()
+	if len(b) != n {
+		return nil, ErrInvalidEncoding
+	}
+	c := append([]byte(nil), b...)
+	sign := c[n-1] >> 7
+	c[n-1] &= 0x7F
+	y := leToBig(c)
+	if y.Cmp(F.P()) >= 0 {
+		return nil, ErrInvalidEncoding
+	}
+	var u, v, x, x2 GF.Elt
+	Y := F.Elt(y)
+	u = F.Sub(F.Sqr(Y), F.One())         // u = y^2 - 1
+	v = F.Sub(F.Mul(e.D, F.Sqr(Y)), e.A) // v = D*y^2 - A
+	if F.IsZero(v) {
+		return nil, ErrNotOnCurve
+	}
+	x2 = F.Mul(u, F.Inv(v)) // x^2 = (y^2 - 1)/(D*y^2 - A)
+	if !F.IsSquare(x2) {
+		return nil, ErrNotOnCurve
+	}
+	x = F.Sqrt(x2)
+	if F.IsZero(x) && sign == 1 {
+		return nil, ErrInvalidEncoding
+	}
+	odd := F.GetSgn0(GF.SignLE)(x) == -1
+	x = F.CMov(x, F.Neg(x), odd != (sign == 1))
+	return e.NewPoint(x, Y), nil
+}
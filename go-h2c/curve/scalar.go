@@ -0,0 +1,45 @@
+package curve
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// windowWidth is the width w of the signed fixed-window scalar multiplication,
+// which uses tables of 2^(w-1) odd multiples of a point.
+const windowWidth = 4
+
+// oddScalar returns |k| if it is odd, or |k|+1 otherwise, together with the
+// parity and the sign of k.
+func oddScalar(k *big.Int) (kk *big.Int, even, neg bool) {
+	kk = new(big.Int).Abs(k)
+	bit := kk.Bit(0)
+	kk.Add(kk, big.NewInt(int64(1-bit)))
+	return kk, bit == 0, k.Sign() < 0
+}
+
+// recodeScalar returns the odd digits d[i] in [-(2^w-1), 2^w-1], where w is
+// windowWidth, such that k = sum d[i]*2^(w*i), for an odd k of at most l bits.
+// The number of digits only depends on l (Joye and Tunstall, "Exponent
+// recoding and regular exponentiation algorithms", Section 3.2).
+func recodeScalar(k *big.Int, l int) []int {
+	m := (l + windowWidth - 1) / windowWidth
+	d := make([]int, m+1)
+	k = new(big.Int).Set(k)
+	mask := big.NewInt(1<<(windowWidth+1) - 1)
+	t := new(big.Int)
+	for i := 0; i < m; i++ {
+		d[i] = int(t.And(k, mask).Int64()) - 1<<windowWidth
+		k.Sub(k, t.SetInt64(int64(d[i])))
+		k.Rsh(k, windowWidth)
+	}
+	d[m] = int(k.Int64())
+	return d
+}
+
+// digitIndex returns the index (|d|-1)/2 of d in a table of odd multiples, and
+// whether d is negative, without branching on d.
+func digitIndex(d int) (int, bool) {
+	s := d >> (bits.UintSize - 1)
+	return ((d ^ s) - s - 1) >> 1, s != 0
+}
@@ -0,0 +1,122 @@
+package curve
+
+import (
+	"math/big"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// eltSize returns the length in bytes of the encoding of a field element,
+// whose coefficients are encoded as big-endian integers.
+func eltSize(f GF.Field) int { return int(f.Ext()) * ((f.P().BitLen() + 7) / 8) }
+
+// eltToBytes encodes a field element as its coefficients as big-endian
+// integers, starting from the constant term (SEC1, Section 2.3.5).
+func eltToBytes(f GF.Field, x GF.Elt) []byte {
+	n := (f.P().BitLen() + 7) / 8
+	b := make([]byte, 0, eltSize(f))
+	for _, c := range f.Coeffs(x) {
+		b = append(b, c.FillBytes(make([]byte, n))...)
+	}
+	return b
+}
+
+// eltFromBytes decodes a field element, it fails if a coefficient is not
+// reduced modulo p.
+func eltFromBytes(f GF.Field, b []byte) (GF.Elt, bool) {
+	n := (f.P().BitLen() + 7) / 8
+	p := f.P()
+	v := make([]interface{}, f.Ext())
+	for i := range v {
+		c := new(big.Int).SetBytes(b[i*n : (i+1)*n])
+		if c.Cmp(p) >= 0 {
+			return nil, false
+		}
+		v[i] = c
+	}
+	return f.Elt(v), true
+}
+
+// Marshal returns the SEC1 encoding of P (SEC1, Section 2.3.3). The point at
+// infinity is encoded as 0x00; otherwise, the compressed form is 0x02 or 0x03
+// followed by x, and the uncompressed form is 0x04 followed by x and y. The
+// sign of y is given by sgn0, which is the parity of y for prime fields.
+func (e *WECurve) Marshal(p Point, compress bool) []byte {
+	if p.IsIdentity() {
+		return []byte{0x00}
+	}
+	P := p.(*ptWe)
+	if compress {
+		prefix := byte(0x02)
+		if e.F.GetSgn0(GF.SignLE)(P.y) == -1 {
+			prefix = 0x03
+		}
+		return append([]byte{prefix}, eltToBytes(e.F, P.x)...)
+	}
+	b := append([]byte{0x04}, eltToBytes(e.F, P.x)...)
+	return append(b, eltToBytes(e.F, P.y)...)
+}
+
+// Unmarshal decodes a point from its SEC1 encoding in either form. It returns
+// an error if the encoding is malformed, or the point is not on the curve or
+// not in the subgroup of prime order.
+func (e *WECurve) Unmarshal(b []byte) (Point, error) {
+	F := e.F
+	n := eltSize(F)
+	var x, y GF.Elt
+	var ok bool
+	switch {
+	case len(b) == 1 && b[0] == 0x00:
+		return e.Identity(), nil
+	case len(b) == 1+n && (b[0] == 0x02 || b[0] == 0x03):
+		if x, ok = eltFromBytes(F, b[1:]); !ok {
+			return nil, ErrInvalidEncoding
+		}
+		y2 := e.EvalRHS(x)
+		if !F.IsSquare(y2) {
+			return nil, ErrNotOnCurve
+		}
+		y = F.Sqrt(y2)
+		// The sign of y = 0 is even, so it is never encoded with 0x03.
+		if F.IsZero(y) && b[0] == 0x03 {
+			return nil, ErrInvalidEncoding
+		}
+		odd := F.GetSgn0(GF.SignLE)(y) == -1
+		y = F.CMov(y, F.Neg(y), odd != (b[0] == 0x03))
+	case len(b) == 1+2*n && b[0] == 0x04:
+		if x, ok = eltFromBytes(F, b[1:1+n]); !ok {
+			return nil, ErrInvalidEncoding
+		}
+		if y, ok = eltFromBytes(F, b[1+n:]); !ok {
+			return nil, ErrInvalidEncoding
+		}
+	default:
+		return nil, ErrInvalidEncoding
+	}
+	P := &ptWe{e, &afPoint{x: x, y: y}}
+	if !e.IsOnCurve(P) {
+		return nil, ErrNotOnCurve
+	}
+	if !e.IsInSubgroup(P) {
+		return nil, ErrNotInSubgroup
+	}
+	return P, nil
+}
+
+// MarshalBinary returns the SEC1 uncompressed encoding of the point.
+func (p *ptWe) MarshalBinary() ([]byte, error) { return p.WECurve.Marshal(p, false), nil }
+
+// UnmarshalBinary sets the point from a SEC1 encoding in either form using the
+// curve of the receiver. Since the receiver cannot hold the point at infinity,
+// it returns an error for its encoding.
+func (p *ptWe) UnmarshalBinary(b []byte) error {
+	q, err := p.WECurve.Unmarshal(b)
+	if err != nil {
+		return err
+	}
+	if q.IsIdentity() {
+		return ErrInfinity
+	}
+	p.afPoint = q.(*ptWe).afPoint
+	return nil
+}
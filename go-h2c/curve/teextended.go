@@ -0,0 +1,110 @@
+package curve
+
+import (
+	"crypto/subtle"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// extPoint is a point (X:Y:Z:T) in extended twisted Edwards coordinates, which
+// represents the affine point (X/Z, Y/Z) with T=XY/Z.
+type extPoint struct{ x, y, z, t GF.Elt }
+
+func (e *TECurve) toExtended(p Point) *extPoint {
+	P := p.(*ptTe)
+	return &extPoint{P.x.Copy(), P.y.Copy(), e.F.One(), e.F.Mul(P.x, P.y)}
+}
+
+func (e *TECurve) toAffine(P *extPoint) Point {
+	F := e.F
+	invZ := F.Inv(P.z)
+	return &ptTe{e, &afPoint{x: F.Mul(P.x, invZ), y: F.Mul(P.y, invZ)}}
+}
+
+// addExt adds points using the unified formulas of Hisil, Wong, Carter and
+// Dawson (https://eprint.iacr.org/2008/522, Section 3.1), which are complete
+// if the curve is complete.
+func (e *TECurve) addExt(P, Q *extPoint) *extPoint {
+	F := e.F
+	var a, b, c, d, ee, f, g, h GF.Elt
+	a = F.Mul(P.x, Q.x)  // A = X1 X2
+	b = F.Mul(P.y, Q.y)  // B = Y1 Y2
+	c = F.Mul(P.t, e.D)  // C = T1 D
+	c = F.Mul(c, Q.t)    // C = T1 D T2
+	d = F.Mul(P.z, Q.z)  // D = Z1 Z2
+	ee = F.Add(P.x, P.y) // E = X1 + Y1
+	f = F.Add(Q.x, Q.y)  // F = X2 + Y2
+	ee = F.Mul(ee, f)    // E = (X1 + Y1)(X2 + Y2)
+	ee = F.Sub(ee, a)    // E = (X1 + Y1)(X2 + Y2) - A
+	ee = F.Sub(ee, b)    // E = (X1 + Y1)(X2 + Y2) - A - B
+	f = F.Sub(d, c)      // F = D - C
+	g = F.Add(d, c)      // G = D + C
+	h = F.Mul(e.A, a)    // H = aA
+	h = F.Sub(b, h)      // H = B - aA
+	return &extPoint{
+		x: F.Mul(ee, f), // X3 = E F
+		y: F.Mul(g, h),  // Y3 = G H
+		z: F.Mul(f, g),  // Z3 = F G
+		t: F.Mul(ee, h), // T3 = E H
+	}
+}
+
+// doubleExt doubles a point using the dedicated formulas of Hisil, Wong, Carter
+// and Dawson (https://eprint.iacr.org/2008/522, Section 3.3).
+func (e *TECurve) doubleExt(P *extPoint) *extPoint {
+	F := e.F
+	var a, b, c, d, ee, f, g, h GF.Elt
+	a = F.Sqr(P.x)       // A = X1^2
+	b = F.Sqr(P.y)       // B = Y1^2
+	c = F.Sqr(P.z)       // C = Z1^2
+	c = F.Add(c, c)      // C = 2 Z1^2
+	d = F.Mul(e.A, a)    // D = aA
+	ee = F.Add(P.x, P.y) // E = X1 + Y1
+	ee = F.Sqr(ee)       // E = (X1 + Y1)^2
+	ee = F.Sub(ee, a)    // E = (X1 + Y1)^2 - A
+	ee = F.Sub(ee, b)    // E = (X1 + Y1)^2 - A - B
+	g = F.Add(d, b)      // G = D + B
+	f = F.Sub(g, c)      // F = G - C
+	h = F.Sub(d, b)      // H = D - B
+	return &extPoint{
+		x: F.Mul(ee, f), // X3 = E F
+		y: F.Mul(g, h),  // Y3 = G H
+		z: F.Mul(f, g),  // Z3 = F G
+		t: F.Mul(ee, h), // T3 = E H
+	}
+}
+
+// cmovExt returns Q if b is true, or P otherwise.
+func (e *TECurve) cmovExt(P, Q *extPoint, b bool) *extPoint {
+	F := e.F
+	return &extPoint{F.CMov(P.x, Q.x, b), F.CMov(P.y, Q.y, b), F.CMov(P.z, Q.z, b), F.CMov(P.t, Q.t, b)}
+}
+
+// cnegExt returns -P if b is true, or P otherwise.
+func (e *TECurve) cnegExt(P *extPoint, b bool) *extPoint {
+	F := e.F
+	return &extPoint{F.CMov(P.x, F.Neg(P.x), b), P.y, P.z, F.CMov(P.t, F.Neg(P.t), b)}
+}
+
+// tableExt returns the odd multiples [1]P, [3]P, ..., [2^w-1]P, where w is
+// windowWidth.
+func (e *TECurve) tableExt(P *extPoint) []*extPoint {
+	T := make([]*extPoint, 1<<(windowWidth-1))
+	P2 := e.doubleExt(P)
+	T[0] = P
+	for i := 1; i < len(T); i++ {
+		T[i] = e.addExt(T[i-1], P2)
+	}
+	return T
+}
+
+// lookupExt returns [d]P from the table T of odd multiples of P. It reads
+// every entry of T, so that the memory access pattern does not depend on d.
+func (e *TECurve) lookupExt(T []*extPoint, d int) *extPoint {
+	idx, neg := digitIndex(d)
+	R := T[0]
+	for j := 1; j < len(T); j++ {
+		R = e.cmovExt(R, T[j], subtle.ConstantTimeEq(int32(j), int32(idx)) == 1)
+	}
+	return e.cnegExt(R, neg)
+}
@@ -1,7 +1,6 @@
 package curve
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -18,20 +17,37 @@ type WC = *WCCurve
 
 func (e *WCCurve) String() string { return "y^2=x^3+Ax^2+Bx\n" + e.params.String() }
 
-// NewWeierstrassC returns a Weierstrass curve
+// NewWeierstrassC returns a Weierstrass curve, it panics if the parameters are
+// invalid.
 func NewWeierstrassC(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) *WCCurve {
+	e, err := NewWeierstrassCE(id, f, a, b, r, h)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// NewWeierstrassCE returns a WeierstrassC curve, or ErrInvalidCurve if the
+// parameters are invalid.
+func NewWeierstrassCE(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) (*WCCurve, error) {
 	if e := (&WCCurve{params: &params{Id: id, F: f, A: a, B: b, R: r, H: h}}); e.IsValid() {
 		e.RationalMap = e.ToWeierstrass()
-		return e
+		return e, nil
 	}
-	panic(errors.New("can't instantiate a WeierstrassC curve"))
+	return nil, ErrInvalidCurve
 }
 
-func (e *WCCurve) NewPoint(x, y GF.Elt) (P Point) {
-	if P = (&ptWc{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
-		return P
+// NewPoint returns the point (x,y), it panics if the point is not on the
+// curve.
+func (e *WCCurve) NewPoint(x, y GF.Elt) Point { return mustPoint(e.NewPointE(x, y)) }
+
+// NewPointE returns the point (x,y), or ErrNotOnCurve if the point is not
+// on the curve.
+func (e *WCCurve) NewPointE(x, y GF.Elt) (Point, error) {
+	if P := (&ptWc{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+		return P, nil
 	}
-	panic(fmt.Errorf("%v not on %v", P, e))
+	return nil, fmt.Errorf("%w: (%v, %v)", ErrNotOnCurve, x, y)
 }
 
 func (e *WCCurve) IsValid() bool {
@@ -44,15 +60,34 @@ func (e *WCCurve) IsValid() bool {
 	return !F.IsZero(t0)  // B(A^2-4B) != 0
 }
 func (e *WCCurve) IsEqual(ec EllCurve) bool {
-	e0 := ec.(*WECurve)
+	e0 := ec.(*WCCurve)
 	return e.F.IsEqual(e0.F) && e.F.AreEqual(e.A, e0.A) && e.F.AreEqual(e.B, e0.B)
 }
-func (e *WCCurve) Identity() Point             { return &infPoint{} }
-func (e *WCCurve) IsOnCurve(p Point) bool      { return e.Codomain().IsOnCurve(e.Push(p)) }
-func (e *WCCurve) Add(p, q Point) Point        { return e.Pull(e.Codomain().Add(e.Push(p), e.Push(q))) }
-func (e *WCCurve) Double(p Point) Point        { return e.Pull(e.Codomain().Double(e.Push(p))) }
-func (e *WCCurve) Neg(p Point) Point           { return e.Pull(e.Codomain().Neg(e.Push(p))) }
-func (e *WCCurve) ClearCofactor(p Point) Point { return e.Pull(e.Codomain().ClearCofactor(e.Push(p))) }
+func (e *WCCurve) IsOnCurve(p Point) bool {
+	if _, isZero := p.(*infPoint); isZero {
+		return isZero
+	}
+	P := p.(*ptWc)
+	F := e.F
+	var t0, t1 GF.Elt
+	t0 = F.Add(P.x, e.A) // x+A
+	t0 = F.Mul(t0, P.x)  // (x+A)x
+	t0 = F.Add(t0, e.B)  // (x+A)x+B
+	t0 = F.Mul(t0, P.x)  // ((x+A)x+B)x
+	t1 = F.Sqr(P.y)      // y^2
+	return F.AreEqual(t0, t1)
+}
+func (e *WCCurve) Identity() Point           { return &infPoint{} }
+func (e *WCCurve) Add(p, q Point) Point      { return e.Pull(e.Codomain().Add(e.Push(p), e.Push(q))) }
+func (e *WCCurve) Double(p Point) Point      { return e.Pull(e.Codomain().Double(e.Push(p))) }
+func (e *WCCurve) Neg(p Point) Point         { return e.Pull(e.Codomain().Neg(e.Push(p))) }
+func (e *WCCurve) IsInSubgroup(p Point) bool { return e.Codomain().IsInSubgroup(e.Push(p)) }
+func (e *WCCurve) ClearCofactor(p Point) Point {
+	if e.clear != nil {
+		return e.clear(e, p)
+	}
+	return e.Pull(e.Codomain().ClearCofactor(e.Push(p)))
+}
 
 // ptWc is an affine point on a WCCurve curve.
 type ptWc struct {
@@ -63,8 +98,8 @@ type ptWc struct {
 func (p *ptWc) String() string { return p.afPoint.String() }
 func (p *ptWc) Copy() Point    { return &ptWc{p.WCCurve, p.copy()} }
 func (p *ptWc) IsEqual(q Point) bool {
-	qq := q.(*ptWc)
-	return p.WCCurve.IsEqual(qq.WCCurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptWc)
+	return ok && p.WCCurve.IsEqual(qq.WCCurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptWc) IsIdentity() bool   { return false }
 func (p *ptWc) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
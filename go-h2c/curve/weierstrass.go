@@ -1,7 +1,6 @@
 package curve
 
 import (
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -15,23 +14,40 @@ type W = *WECurve
 
 func (e *WECurve) String() string { return "y^2=x^3+Ax+B\n" + e.params.String() }
 
-// NewWeierstrass returns a Weierstrass curve
+// NewWeierstrass returns a Weierstrass curve, it panics if the parameters are
+// invalid.
 func NewWeierstrass(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) *WECurve {
+	e, err := NewWeierstrassE(id, f, a, b, r, h)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// NewWeierstrassE returns a Weierstrass curve, or ErrInvalidCurve if the
+// parameters are invalid.
+func NewWeierstrassE(id CurveID, f GF.Field, a, b GF.Elt, r, h *big.Int) (*WECurve, error) {
 	if e := (&WECurve{&params{
 		Id: id, F: f, A: a, B: b, R: r, H: h,
 	}}); e.IsValid() {
-		return e
+		return e, nil
 	}
-	panic(errors.New("can't instantiate a Weierstrass curve"))
+	return nil, ErrInvalidCurve
 }
 
-// NewPoint generates
-func (e *WECurve) NewPoint(x, y GF.Elt) (P Point) {
-	if P = (&ptWe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
-		return P
+// NewPoint returns the point (x,y), it panics if the point is not on the
+// curve.
+func (e *WECurve) NewPoint(x, y GF.Elt) Point { return mustPoint(e.NewPointE(x, y)) }
+
+// NewPointE returns the point (x,y), or ErrNotOnCurve if the point is not
+// on the curve.
+func (e *WECurve) NewPointE(x, y GF.Elt) (Point, error) {
+	if P := (&ptWe{e, &afPoint{x: x, y: y}}); e.IsOnCurve(P) {
+		return P, nil
 	}
-	panic(fmt.Errorf("p=%v not on curve", P))
+	return nil, fmt.Errorf("%w: (%v, %v)", ErrNotOnCurve, x, y)
 }
+
 func (e *WECurve) IsValid() bool {
 	F := e.F
 	t0 := F.Sqr(e.A)          // A^2
@@ -71,6 +87,19 @@ func (e *WECurve) EvalRHS(x GF.Elt) GF.Elt {
 }
 func (e *WECurve) Identity() Point { return &infPoint{} }
 func (e *WECurve) Add(p, q Point) Point {
+	if !e.IsComplete() {
+		return e.addAffine(p, q)
+	}
+	return e.toAffine(e.addPrj(e.toProjective(p), e.toProjective(q)))
+}
+
+// IsComplete returns true if the curve has odd order, so it has no points of
+// order two and the formulas of addPrj are complete.
+func (e *WECurve) IsComplete() bool { return e.R.Bit(0) == 1 && e.H.Bit(0) == 1 }
+
+// addAffine adds points using affine formulas, which are used on curves of
+// even order, where the formulas of addPrj are not complete.
+func (e *WECurve) addAffine(p, q Point) Point {
 	if p.IsIdentity() {
 		return q.Copy()
 	} else if q.IsIdentity() {
@@ -78,7 +107,7 @@ func (e *WECurve) Add(p, q Point) Point {
 	} else if p.IsEqual(e.Neg(q)) {
 		return e.Identity()
 	} else if p.IsEqual(q) {
-		return e.Double(p)
+		return e.toAffine(e.doublePrj(e.toProjective(p)))
 	} else {
 		return e.add(p, q)
 	}
@@ -116,44 +145,97 @@ func (e *WECurve) add(p, q Point) Point {
 	return &ptWe{e, &afPoint{x: x, y: y}}
 }
 func (e *WECurve) Double(p Point) Point {
-	if _, ok := p.(*infPoint); ok {
-		return e.Identity()
-	}
-	P := p.(*ptWe)
-	if P.IsTwoTorsion() {
-		return e.Identity()
-	}
-
-	F := e.F
-	var t0, t1, ll GF.Elt
-	t0 = F.Sqr(P.x)          // x^2
-	t0 = F.Mul(t0, F.Elt(3)) // 3x^2
-	t0 = F.Add(t0, e.A)      // 3x^2+A
-	t1 = F.Add(P.y, P.y)     // 2y
-	t1 = F.Inv(t1)           // 1/2y
-	ll = F.Mul(t0, t1)       // l = (3x^2+2A)/(2y)
-
-	t0 = F.Sqr(ll)      // l^2
-	t0 = F.Sub(t0, P.x) // l^2-x
-	x := F.Sub(t0, P.x) // x' = l^2-2x
+	return e.toAffine(e.doublePrj(e.toProjective(p)))
+}
 
-	t0 = F.Sub(P.x, x)  // x-x'
-	t0 = F.Mul(t0, ll)  // l(x-x')
-	y := F.Sub(t0, P.y) // y3 = l(x-x')-y1
+// ScalarMult returns [k]P using a signed fixed window, complete formulas, and
+// table lookups that do not depend on k, so that the sequence of operations
+// only depends on the bit length of r, or of k if it is larger. For curves
+// registered with GLV parameters, it uses the endomorphism to halve the number
+// of doublings on points of the subgroup of prime order. Curves of even order
+// have no complete formulas, so ScalarMult uses the double-and-add method on
+// them, which is not constant time.
+func (e *WECurve) ScalarMult(p Point, k *big.Int) Point {
+	switch {
+	case !e.IsComplete():
+		Q := e.scalarMultBinary(p, new(big.Int).Abs(k))
+		if k.Sign() < 0 {
+			Q = e.Neg(Q)
+		}
+		return Q
+	case e.glv != nil && e.IsInSubgroup(p):
+		return e.scalarMultGLV(p, k)
+	default:
+		return e.scalarMultWindow(p, k)
+	}
+}
 
-	return &ptWe{e, &afPoint{x: x, y: y}}
+// scalarMultWindow returns [k]P using the signed fixed window of width
+// windowWidth.
+func (e *WECurve) scalarMultWindow(p Point, k *big.Int) Point {
+	P := e.toProjective(p)
+	kk, even, neg := oddScalar(k)
+	d := recodeScalar(kk, max(kk.BitLen(), e.R.BitLen()))
+	T := e.tablePrj(P)
+	Q := e.lookupPrj(T, d[len(d)-1])
+	for i := len(d) - 2; i >= 0; i-- {
+		for j := 0; j < windowWidth; j++ {
+			Q = e.doublePrj(Q)
+		}
+		Q = e.addPrj(Q, e.lookupPrj(T, d[i]))
+	}
+	Q = e.cmovPrj(Q, e.addPrj(Q, e.cnegPrj(P, true)), even)
+	return e.toAffine(e.cnegPrj(Q, neg))
 }
-func (e *WECurve) ScalarMult(p Point, k *big.Int) Point {
-	Q := e.Identity()
+
+// scalarMultBinary returns [k]P using the double-and-add method, which works
+// for every point on the curve. It is not constant time, so it is only used
+// for public scalars, and on curves of even order.
+func (e *WECurve) scalarMultBinary(p Point, k *big.Int) Point {
+	if !e.IsComplete() {
+		Q := e.Identity()
+		for i := k.BitLen() - 1; i >= 0; i-- {
+			Q = e.Double(Q)
+			if k.Bit(i) != 0 {
+				Q = e.addAffine(Q, p)
+			}
+		}
+		return Q
+	}
+	P := e.toProjective(p)
+	Q := e.toProjective(e.Identity())
 	for i := k.BitLen() - 1; i >= 0; i-- {
-		Q = e.Double(Q)
+		Q = e.doublePrj(Q)
 		if k.Bit(i) != 0 {
-			Q = e.Add(Q, p)
+			Q = e.addPrj(Q, P)
 		}
 	}
-	return Q
+	return e.toAffine(Q)
+}
+func (e *WECurve) ClearCofactor(p Point) Point {
+	if e.clear != nil {
+		return e.clear(e, p)
+	}
+	return e.scalarMultBinary(p, e.H)
+}
+
+// IsInSubgroup returns true if [r]P is the identity, where r is the order of
+// the prime-order subgroup. For BLS12381G1 and BLS12381G2, it uses faster
+// tests based on endomorphisms.
+func (e *WECurve) IsInSubgroup(p Point) bool {
+	switch {
+	case p.IsIdentity():
+		return true
+	case e.Id == BLS12381G1:
+		return e.isInSubgroupBLS12381G1(p)
+	case e.Id == BLS12381G2:
+		return e.isInSubgroupBLS12381G2(p)
+	case e.H.Cmp(big.NewInt(1)) == 0:
+		return true
+	default:
+		return e.scalarMultBinary(p, e.R).IsIdentity()
+	}
 }
-func (e *WECurve) ClearCofactor(p Point) Point { return e.ScalarMult(p, e.H) }
 
 // ptWe is an affine point on a WECurve curve.
 type ptWe struct {
@@ -164,8 +246,8 @@ type ptWe struct {
 func (p *ptWe) String() string { return p.afPoint.String() }
 func (p *ptWe) Copy() Point    { return &ptWe{p.WECurve, p.copy()} }
 func (p *ptWe) IsEqual(q Point) bool {
-	qq := q.(*ptWe)
-	return p.WECurve.IsEqual(qq.WECurve) && p.isEqual(p.F, qq.afPoint)
+	qq, ok := q.(*ptWe)
+	return ok && p.WECurve.IsEqual(qq.WECurve) && p.isEqual(p.F, qq.afPoint)
 }
 func (p *ptWe) IsIdentity() bool   { return false }
 func (p *ptWe) IsTwoTorsion() bool { return p.F.IsZero(p.y) }
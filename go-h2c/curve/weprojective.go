@@ -0,0 +1,155 @@
+package curve
+
+import (
+	"crypto/subtle"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// prjPoint is a point (X:Y:Z) in projective coordinates, which represents the
+// affine point (X/Z, Y/Z) if Z!=0, or the point at infinity if Z=0.
+type prjPoint struct{ x, y, z GF.Elt }
+
+func (e *WECurve) toProjective(p Point) *prjPoint {
+	F := e.F
+	if p.IsIdentity() {
+		return &prjPoint{F.Zero(), F.One(), F.Zero()}
+	}
+	P := p.(*ptWe)
+	return &prjPoint{P.x.Copy(), P.y.Copy(), F.One()}
+}
+
+func (e *WECurve) toAffine(P *prjPoint) Point {
+	F := e.F
+	if F.IsZero(P.z) {
+		return e.Identity()
+	}
+	invZ := F.Inv(P.z)
+	return &ptWe{e, &afPoint{x: F.Mul(P.x, invZ), y: F.Mul(P.y, invZ)}}
+}
+
+// addPrj adds points using the complete formulas of Renes, Costello and Batina
+// (https://eprint.iacr.org/2015/1060, Algorithm 1). The formulas fail only if
+// P-Q is a point of order two, so they are complete on curves of odd order,
+// and addPrj must not be used on other curves, see IsComplete.
+func (e *WECurve) addPrj(P, Q *prjPoint) *prjPoint {
+	F := e.F
+	b3 := F.Mul(F.Elt(3), e.B)
+	var t0, t1, t2, t3, t4, t5, X3, Y3, Z3 GF.Elt
+	t0 = F.Mul(P.x, Q.x) // 1.  t0 = X1 X2
+	t1 = F.Mul(P.y, Q.y) // 2.  t1 = Y1 Y2
+	t2 = F.Mul(P.z, Q.z) // 3.  t2 = Z1 Z2
+	t3 = F.Add(P.x, P.y) // 4.  t3 = X1 + Y1
+	t4 = F.Add(Q.x, Q.y) // 5.  t4 = X2 + Y2
+	t3 = F.Mul(t3, t4)   // 6.  t3 = t3 t4
+	t4 = F.Add(t0, t1)   // 7.  t4 = t0 + t1
+	t3 = F.Sub(t3, t4)   // 8.  t3 = t3 - t4
+	t4 = F.Add(P.x, P.z) // 9.  t4 = X1 + Z1
+	t5 = F.Add(Q.x, Q.z) // 10. t5 = X2 + Z2
+	t4 = F.Mul(t4, t5)   // 11. t4 = t4 t5
+	t5 = F.Add(t0, t2)   // 12. t5 = t0 + t2
+	t4 = F.Sub(t4, t5)   // 13. t4 = t4 - t5
+	t5 = F.Add(P.y, P.z) // 14. t5 = Y1 + Z1
+	X3 = F.Add(Q.y, Q.z) // 15. X3 = Y2 + Z2
+	t5 = F.Mul(t5, X3)   // 16. t5 = t5 X3
+	X3 = F.Add(t1, t2)   // 17. X3 = t1 + t2
+	t5 = F.Sub(t5, X3)   // 18. t5 = t5 - X3
+	Z3 = F.Mul(e.A, t4)  // 19. Z3 = a t4
+	X3 = F.Mul(b3, t2)   // 20. X3 = b3 t2
+	Z3 = F.Add(X3, Z3)   // 21. Z3 = X3 + Z3
+	X3 = F.Sub(t1, Z3)   // 22. X3 = t1 - Z3
+	Z3 = F.Add(t1, Z3)   // 23. Z3 = t1 + Z3
+	Y3 = F.Mul(X3, Z3)   // 24. Y3 = X3 Z3
+	t1 = F.Add(t0, t0)   // 25. t1 = t0 + t0
+	t1 = F.Add(t1, t0)   // 26. t1 = t1 + t0
+	t2 = F.Mul(e.A, t2)  // 27. t2 = a t2
+	t4 = F.Mul(b3, t4)   // 28. t4 = b3 t4
+	t1 = F.Add(t1, t2)   // 29. t1 = t1 + t2
+	t2 = F.Sub(t0, t2)   // 30. t2 = t0 - t2
+	t2 = F.Mul(e.A, t2)  // 31. t2 = a t2
+	t4 = F.Add(t4, t2)   // 32. t4 = t4 + t2
+	t0 = F.Mul(t1, t4)   // 33. t0 = t1 t4
+	Y3 = F.Add(Y3, t0)   // 34. Y3 = Y3 + t0
+	t0 = F.Mul(t5, t4)   // 35. t0 = t5 t4
+	X3 = F.Mul(t3, X3)   // 36. X3 = t3 X3
+	X3 = F.Sub(X3, t0)   // 37. X3 = X3 - t0
+	t0 = F.Mul(t3, t1)   // 38. t0 = t3 t1
+	Z3 = F.Mul(t5, Z3)   // 39. Z3 = t5 Z3
+	Z3 = F.Add(Z3, t0)   // 40. Z3 = Z3 + t0
+	return &prjPoint{X3, Y3, Z3}
+}
+
+// doublePrj doubles a point using the complete formulas of Renes, Costello and
+// Batina (https://eprint.iacr.org/2015/1060, Algorithm 3).
+func (e *WECurve) doublePrj(P *prjPoint) *prjPoint {
+	F := e.F
+	b3 := F.Mul(F.Elt(3), e.B)
+	var t0, t1, t2, t3, X3, Y3, Z3 GF.Elt
+	t0 = F.Sqr(P.x)      // 1.  t0 = X X
+	t1 = F.Sqr(P.y)      // 2.  t1 = Y Y
+	t2 = F.Sqr(P.z)      // 3.  t2 = Z Z
+	t3 = F.Mul(P.x, P.y) // 4.  t3 = X Y
+	t3 = F.Add(t3, t3)   // 5.  t3 = t3 + t3
+	Z3 = F.Mul(P.x, P.z) // 6.  Z3 = X Z
+	Z3 = F.Add(Z3, Z3)   // 7.  Z3 = Z3 + Z3
+	X3 = F.Mul(e.A, Z3)  // 8.  X3 = a Z3
+	Y3 = F.Mul(b3, t2)   // 9.  Y3 = b3 t2
+	Y3 = F.Add(X3, Y3)   // 10. Y3 = X3 + Y3
+	X3 = F.Sub(t1, Y3)   // 11. X3 = t1 - Y3
+	Y3 = F.Add(t1, Y3)   // 12. Y3 = t1 + Y3
+	Y3 = F.Mul(X3, Y3)   // 13. Y3 = X3 Y3
+	X3 = F.Mul(t3, X3)   // 14. X3 = t3 X3
+	Z3 = F.Mul(b3, Z3)   // 15. Z3 = b3 Z3
+	t2 = F.Mul(e.A, t2)  // 16. t2 = a t2
+	t3 = F.Sub(t0, t2)   // 17. t3 = t0 - t2
+	t3 = F.Mul(e.A, t3)  // 18. t3 = a t3
+	t3 = F.Add(t3, Z3)   // 19. t3 = t3 + Z3
+	Z3 = F.Add(t0, t0)   // 20. Z3 = t0 + t0
+	t0 = F.Add(Z3, t0)   // 21. t0 = Z3 + t0
+	t0 = F.Add(t0, t2)   // 22. t0 = t0 + t2
+	t0 = F.Mul(t0, t3)   // 23. t0 = t0 t3
+	Y3 = F.Add(Y3, t0)   // 24. Y3 = Y3 + t0
+	t2 = F.Mul(P.y, P.z) // 25. t2 = Y Z
+	t2 = F.Add(t2, t2)   // 26. t2 = t2 + t2
+	t0 = F.Mul(t2, t3)   // 27. t0 = t2 t3
+	X3 = F.Sub(X3, t0)   // 28. X3 = X3 - t0
+	Z3 = F.Mul(t2, t1)   // 29. Z3 = t2 t1
+	Z3 = F.Add(Z3, Z3)   // 30. Z3 = Z3 + Z3
+	Z3 = F.Add(Z3, Z3)   // 31. Z3 = Z3 + Z3
+	return &prjPoint{X3, Y3, Z3}
+}
+
+// cmovPrj returns Q if b is true, or P otherwise.
+func (e *WECurve) cmovPrj(P, Q *prjPoint, b bool) *prjPoint {
+	F := e.F
+	return &prjPoint{F.CMov(P.x, Q.x, b), F.CMov(P.y, Q.y, b), F.CMov(P.z, Q.z, b)}
+}
+
+// cnegPrj returns -P if b is true, or P otherwise.
+func (e *WECurve) cnegPrj(P *prjPoint, b bool) *prjPoint {
+	F := e.F
+	return &prjPoint{P.x, F.CMov(P.y, F.Neg(P.y), b), P.z}
+}
+
+// tablePrj returns the odd multiples [1]P, [3]P, ..., [2^w-1]P, where w is
+// windowWidth.
+func (e *WECurve) tablePrj(P *prjPoint) []*prjPoint {
+	T := make([]*prjPoint, 1<<(windowWidth-1))
+	P2 := e.doublePrj(P)
+	T[0] = P
+	for i := 1; i < len(T); i++ {
+		T[i] = e.addPrj(T[i-1], P2)
+	}
+	return T
+}
+
+// lookupPrj returns [d]P from the table T of odd multiples of P. It reads
+// every entry of T, so that the memory access pattern does not depend on d.
+func (e *WECurve) lookupPrj(T []*prjPoint, d int) *prjPoint {
+	idx, neg := digitIndex(d)
+	R := T[0]
+	for j := 1; j < len(T); j++ {
+		R = e.cmovPrj(R, T[j], subtle.ConstantTimeEq(int32(j), int32(idx)) == 1)
+	}
+	return e.cnegPrj(R, neg)
+}
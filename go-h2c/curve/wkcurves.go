@@ -1,7 +1,9 @@
 package curve
 
 import (
+	"fmt"
 	"math/big"
+	"sync"
 
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 )
@@ -22,89 +24,227 @@ const (
 	BLS12381G1
 	BLS12381G1_11ISO
 	BLS12381G2
+	BLS12381G2_3ISO
 )
 
-// GetFromID is
-func (id CurveID) Get() EllCurve {
+var scalarFields = struct {
+	sync.Mutex
+	m map[scalarFieldKey]GF.Field
+}{m: make(map[scalarFieldKey]GF.Field)}
+
+type scalarFieldKey struct {
+	id CurveID
+	b  GF.Backend
+}
+
+// ScalarField returns the prime field of integers modulo the order of the
+// prime-order subgroup of the curve. The field is built once per curve. It
+// panics if the curve is not supported, see ScalarFieldE.
+func (id CurveID) ScalarField() GF.Field {
+	f, err := id.ScalarFieldE(GF.BigInt)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ScalarFieldE is like ScalarField but the field is implemented by the
+// backend b. It returns ErrUnsupported if the curve is not supported, or if b
+// is Specialized since there is no arithmetic generated for scalar fields.
+func (id CurveID) ScalarFieldE(b GF.Backend) (GF.Field, error) {
+	newFp := GF.NewFpE
+	switch b {
+	case GF.BigInt:
+	case GF.ConstantTime:
+		newFp = GF.NewFpCTE
+	default:
+		return nil, fmt.Errorf("%w: scalar field with backend %v", ErrUnsupported, int(b))
+	}
+	scalarFields.Lock()
+	defer scalarFields.Unlock()
+	key := scalarFieldKey{id, b}
+	if f, ok := scalarFields.m[key]; ok {
+		return f, nil
+	}
+	e, err := id.TryGet()
+	if err != nil {
+		return nil, err
+	}
+	f, err := newFp(0, e.Order())
+	if err != nil {
+		return nil, err
+	}
+	scalarFields.m[key] = f
+	return f, nil
+}
+
+// Get returns the curve corresponding to the identifier. It panics if the
+// curve is not supported, see TryGet.
+func (id CurveID) Get() EllCurve { return id.GetBackend(GF.BigInt) }
+
+// TryGet returns the curve corresponding to the identifier, or ErrUnsupported
+// if the curve is not supported.
+func (id CurveID) TryGet() (EllCurve, error) { return id.TryGetBackend(GF.BigInt) }
+
+// GetBackend is like Get but the field of the curve uses the backend b.
+func (id CurveID) GetBackend(b GF.Backend) EllCurve {
+	e, err := id.TryGetBackend(b)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// TryGetBackend is like TryGet but the field of the curve uses the backend b.
+func (id CurveID) TryGetBackend(b GF.Backend) (EllCurve, error) {
+	fid, ok := curveFields[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, int(id))
+	}
+	f, err := fid.TryGetBackend(b)
+	if err != nil {
+		return nil, err
+	}
+	return id.over(f)
+}
+
+// curveFields maps each curve to the identifier of its field of definition.
+var curveFields = map[CurveID]GF.ID{
+	P256:             GF.P256,
+	P384:             GF.P384,
+	P521:             GF.P521,
+	Curve25519:       GF.P25519,
+	Curve448:         GF.P448,
+	Edwards25519:     GF.P25519,
+	Edwards448:       GF.P448,
+	SECP256K1:        GF.P256K1,
+	SECP256K1_3ISO:   GF.P256K1,
+	BLS12381G1:       GF.BLS12381,
+	BLS12381G1_11ISO: GF.BLS12381,
+	BLS12381G2:       GF.BLS12381Fp2,
+	BLS12381G2_3ISO:  GF.BLS12381Fp2,
+}
+
+// over returns the curve corresponding to the identifier defined over f,
+// which must be the field given by curveFields.
+func (id CurveID) over(f GF.Field) (EllCurve, error) {
+	var e EllCurve
+	var err error
 	switch id {
 	case P256:
-		f := GF.P256.Get()
-		return NewWeierstrass(id, f,
+		e, err = NewWeierstrassE(id, f,
 			f.Elt("-3"),
 			f.Elt("0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b"),
 			GF.FromType("0xffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551"),
 			big.NewInt(1))
 	case P384:
-		f := GF.P384.Get()
-		return NewWeierstrass(id, f,
+		e, err = NewWeierstrassE(id, f,
 			f.Elt("-3"),
 			f.Elt("0xb3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875ac656398d8a2ed19d2a85c8edd3ec2aef"),
 			GF.FromType("0xffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973"),
 			big.NewInt(1))
 	case P521:
-		f := GF.P521.Get()
-		return NewWeierstrass(id, f,
+		e, err = NewWeierstrassE(id, f,
 			f.Elt("-3"),
 			f.Elt("0x051953eb9618e1c9a1f929a21a0b68540eea2da725b99b315f3b8b489918ef109e156193951ec7e937b1652c0bd3bb1bf073573df883d2c34f1ef451fd46b503f00"),
 			GF.FromType("0x7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd15b6c64746fc85f736b8af5e7ec53f04fbd8c4569a8f1f4540ea2435f5180d6b"),
 			big.NewInt(1))
 	case SECP256K1:
-		f := GF.P256K1.Get()
-		return NewWeierstrass(id, f,
+		w, err := NewWeierstrassE(id, f,
 			f.Zero(),
 			f.Elt("7"),
 			GF.FromType("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
 			big.NewInt(1))
+		if err != nil {
+			return nil, err
+		}
+		return w, w.SetGLV(&GLV{
+			Beta:   f.Elt("0x7ae96a2b657c07106e64479eac3434e99cf0497512f58995c1396c28719501ee"),
+			Lambda: GF.FromType("0x5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72"),
+			A1:     GF.FromType("0x3086d221a7d46bcde86c90e49284eb15"),
+			B1:     GF.FromType("-0xe4437ed6010e88286f547fa90abfe4c3"),
+			A2:     GF.FromType("0x114ca50f7a8e2f3f657c1108d9d44cfd8"),
+			B2:     GF.FromType("0x3086d221a7d46bcde86c90e49284eb15"),
+		})
 	case SECP256K1_3ISO:
-		f := GF.P256K1.Get()
-		return NewWeierstrass(id, f,
+		e, err = NewWeierstrassE(id, f,
 			f.Elt("0x3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533"),
 			f.Elt("1771"),
 			GF.FromType("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
 			big.NewInt(1))
 	case Curve25519:
-		f := GF.P25519.Get()
-		return NewMontgomery(id, f,
+		e, err = NewMontgomeryE(id, f,
 			f.Elt("486662"),
 			f.One(),
 			GF.FromType("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed"),
 			big.NewInt(8))
 	case Edwards25519:
-		f := GF.P25519.Get()
-		return NewEdwards(id, f,
+		e, err = NewEdwardsE(id, f,
 			f.Elt("-1"),
 			f.Elt("0x52036cee2b6ffe738cc740797779e89800700a4d4141d8ab75eb4dca135978a3"),
 			GF.FromType("0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed"),
 			big.NewInt(8))
 	case Curve448:
-		f := GF.P448.Get()
-		return NewMontgomery(id, f,
+		e, err = NewMontgomeryE(id, f,
 			f.Elt("156326"),
 			f.One(),
 			GF.FromType("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3"),
 			big.NewInt(4))
 	case Edwards448:
-		f := GF.P448.Get()
-		return NewEdwards(id, f,
+		e, err = NewEdwardsE(id, f,
 			f.One(),
 			f.Elt("-39081"),
 			GF.FromType("0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffff7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3"),
 			big.NewInt(4))
 	case BLS12381G1:
-		f := GF.BLS12381.Get()
-		return NewWeierstrass(id, f,
+		w, err := NewWeierstrassE(id, f,
 			f.Zero(),
 			f.Elt(4),
 			GF.FromType("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
-			GF.FromType("0xd201000000010001"))
+			GF.FromType("0x396c8c005555e1568c00aaab0000aaab"))
+		if err != nil {
+			return nil, err
+		}
+		// Effective cofactor h_eff = 1 - z (RFC 9380, Section 8.8.1).
+		w.SetCofactorClearing(ClearCofactorBy(GF.FromType("0xd201000000010001")))
+		// lambda = -z^2 mod r, and the basis is (1, 1-z^2), (z^2, 1).
+		return w, w.SetGLV(&GLV{
+			Beta:   f.Elt(betaBLS12381G1),
+			Lambda: GF.FromType("0x73eda753299d7d483339d80809a1d804a7780001fffcb7fcfffffffe00000001"),
+			A1:     big.NewInt(1),
+			B1:     GF.FromType("-0xac45a4010001a40200000000ffffffff"),
+			A2:     GF.FromType("0xac45a4010001a4020000000100000000"),
+			B2:     big.NewInt(1),
+		})
 	case BLS12381G1_11ISO:
-		f := GF.BLS12381.Get()
-		return NewWeierstrass(id, f,
+		e, err = NewWeierstrassE(id, f,
 			f.Elt("0x144698a3b8e9433d693a02c96d4982b0ea985383ee66a8d8e8981aefd881ac98936f8da0e0f97f5cf428082d584c1d"),
 			f.Elt("0x12e2908d11688030018b12e8753eee3b2016c1f0f24f4070a0b9c14fcef35ef55a23215a316ceaa5d1cc48e98e172be0"),
 			GF.FromType("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
-			GF.FromType("0xd201000000010001"))
+			GF.FromType("0x396c8c005555e1568c00aaab0000aaab"))
+	case BLS12381G2:
+		w, err := NewWeierstrassE(id, f,
+			f.Zero(),
+			f.Elt([]interface{}{4, 4}),
+			GF.FromType("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
+			GF.FromType("0x5d543a95414e7f1091d50792876a202cd91de4547085abaa68a205b2e5a7ddfa628f1cb4d9e82ef21537e293a6691ae1616ec6e786f0c70cf1c38e31c7238e5"))
+		if err != nil {
+			return nil, err
+		}
+		// Effective cofactor h_eff computed with psi (RFC 9380, Section 8.8.2).
+		w.SetCofactorClearing(clearCofactorBLS12381G2)
+		return w, nil
+	case BLS12381G2_3ISO:
+		e, err = NewWeierstrassE(id, f,
+			f.Elt([]interface{}{0, 240}),
+			f.Elt([]interface{}{1012, 1012}),
+			GF.FromType("0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
+			GF.FromType("0x5d543a95414e7f1091d50792876a202cd91de4547085abaa68a205b2e5a7ddfa628f1cb4d9e82ef21537e293a6691ae1616ec6e786f0c70cf1c38e31c7238e5"))
 	default:
-		panic("curve not supported")
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, int(id))
+	}
+	if err != nil {
+		return nil, err
 	}
+	return e, nil
 }
@@ -0,0 +1,143 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// Flags of the most significant byte of the ZCash serialization.
+const (
+	zcashCompressed byte = 1 << 7 // The point is compressed.
+	zcashInfinity   byte = 1 << 6 // The point is the point at infinity.
+	zcashSign       byte = 1 << 5 // The y-coordinate is lexicographically largest.
+)
+
+// zcashEltToBytes encodes a field element as its coefficients as big-endian
+// integers, starting from the coefficient of highest degree.
+func zcashEltToBytes(f GF.Field, x GF.Elt) []byte {
+	n := (f.P().BitLen() + 7) / 8
+	c := f.Coeffs(x)
+	b := make([]byte, 0, len(c)*n)
+	for i := len(c) - 1; i >= 0; i-- {
+		b = append(b, c[i].FillBytes(make([]byte, n))...)
+	}
+	return b
+}
+
+// zcashEltFromBytes decodes a field element, it fails if a coefficient is not
+// reduced modulo p.
+func zcashEltFromBytes(f GF.Field, b []byte) (GF.Elt, bool) {
+	n := (f.P().BitLen() + 7) / 8
+	p := f.P()
+	m := int(f.Ext())
+	v := make([]interface{}, m)
+	for i := range v {
+		c := new(big.Int).SetBytes(b[i*n : (i+1)*n])
+		if c.Cmp(p) >= 0 {
+			return nil, false
+		}
+		v[m-1-i] = c
+	}
+	return f.Elt(v), true
+}
+
+func (e *WECurve) checkZCash() error {
+	if e.Id != BLS12381G1 && e.Id != BLS12381G2 {
+		return fmt.Errorf("%w: no ZCash serialization for %v", ErrUnsupported, e)
+	}
+	return nil
+}
+
+// MarshalZCash returns the ZCash serialization of a point of BLS12381G1 or
+// BLS12381G2, which is used by pairing libraries (draft-irtf-cfrg-pairing-
+// friendly-curves, Appendix C). The three most significant bits of the
+// encoding are flags that indicate compression, the point at infinity, and
+// the sign of y when compressed. Coordinates over Fp2 are encoded as c1||c0.
+// It panics for other curves.
+func (e *WECurve) MarshalZCash(p Point, compress bool) []byte {
+	if err := e.checkZCash(); err != nil {
+		panic(err)
+	}
+	n := eltSize(e.F)
+	var b []byte
+	if compress {
+		b = make([]byte, n)
+	} else {
+		b = make([]byte, 2*n)
+	}
+	if p.IsIdentity() {
+		b[0] = zcashInfinity
+	} else {
+		P := p.(*ptWe)
+		copy(b, zcashEltToBytes(e.F, P.x))
+		if !compress {
+			copy(b[n:], zcashEltToBytes(e.F, P.y))
+		} else if e.F.GetSgn0(GF.SignBE)(P.y) == -1 {
+			b[0] |= zcashSign
+		}
+	}
+	if compress {
+		b[0] |= zcashCompressed
+	}
+	return b
+}
+
+// UnmarshalZCash decodes a point of BLS12381G1 or BLS12381G2 from its ZCash
+// serialization in either form. It returns an error if the encoding is
+// malformed, or the point is not on the curve or not in the subgroup of prime
+// order.
+func (e *WECurve) UnmarshalZCash(b []byte) (Point, error) {
+	if err := e.checkZCash(); err != nil {
+		return nil, err
+	}
+	F := e.F
+	n := eltSize(F)
+	if len(b) == 0 {
+		return nil, ErrInvalidEncoding
+	}
+	compress := b[0]&zcashCompressed != 0
+	infinity := b[0]&zcashInfinity != 0
+	sign := b[0]&zcashSign != 0
+	if (compress && len(b) != n) || (!compress && len(b) != 2*n) || (!compress && sign) {
+		return nil, ErrInvalidEncoding
+	}
+	c := append([]byte(nil), b...)
+	c[0] &^= zcashCompressed | zcashInfinity | zcashSign
+	if infinity {
+		if sign {
+			return nil, ErrInvalidEncoding
+		}
+		for _, v := range c {
+			if v != 0 {
+				return nil, ErrInvalidEncoding
+			}
+		}
+		return e.Identity(), nil
+	}
+	x, ok := zcashEltFromBytes(F, c[:n])
+	if !ok {
+		return nil, ErrInvalidEncoding
+	}
+	var y GF.Elt
+	if compress {
+		y2 := e.EvalRHS(x)
+		if !F.IsSquare(y2) {
+			return nil, ErrNotOnCurve
+		}
+		y = F.Sqrt(y2)
+		largest := F.GetSgn0(GF.SignBE)(y) == -1
+		y = F.CMov(y, F.Neg(y), largest != sign)
+	} else if y, ok = zcashEltFromBytes(F, c[n:]); !ok {
+		return nil, ErrInvalidEncoding
+	}
+	P := &ptWe{e, &afPoint{x: x, y: y}}
+	if !e.IsOnCurve(P) {
+		return nil, ErrNotOnCurve
+	}
+	if !e.IsInSubgroup(P) {
+		return nil, ErrNotInSubgroup
+	}
+	return P, nil
+}
@@ -2,10 +2,11 @@
 Package h2c provides implementations of hashing functions that take
 arbitrary-length byte strings and output a point on an elliptic curve.
 
-These methods follow the current work-in-progress standardization effort
-at IETF https://datatracker.ietf.org/doc/draft-irtf-cfrg-hash-to-curve.
+These methods follow RFC 9380 https://www.rfc-editor.org/rfc/rfc9380, and
+suites are named as in the RFC, for example "P256_XMD:SHA-256_SSWU_RO_".
 
-Version: draft-irtf-cfrg-hash-to-curve-05
+The suites named as in draft-irtf-cfrg-hash-to-curve-05, for example
+"P256-SHA256-SSWU-RO-", are deprecated and kept to reproduce draft-05 outputs.
 
 */
 package h2c
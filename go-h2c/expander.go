@@ -0,0 +1,195 @@
+package h2c
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// oversizeDSTPrefix is prepended to DSTs longer than maxDSTLength before
+// reducing them with the hash function (RFC 9380, Section 5.3.3).
+var oversizeDSTPrefix = []byte("H2C-OVERSIZE-DST-")
+
+const (
+	maxDSTLength      = 255
+	maxXMDBlocks      = 255
+	maxExpandedLength = 65535
+)
+
+// Errors returned when hashing.
+var (
+	// ErrEmptyDST is returned when the domain separation tag is empty.
+	ErrEmptyDST = errors.New("h2c: DST must have nonzero length")
+	// ErrLongOutput is returned when the requested output is too long.
+	ErrLongOutput = errors.New("h2c: requested output is too long")
+	// ErrInvalidHash is returned when the hash function is not available.
+	ErrInvalidHash = errors.New("h2c: hash function not available")
+	// ErrInvalidXOF is returned when the extendable-output function is not
+	// available.
+	ErrInvalidXOF = errors.New("h2c: extendable-output function not available")
+	// ErrUnsupportedSuite is returned for suites that are not registered.
+	ErrUnsupportedSuite = errors.New("h2c: suite not supported")
+)
+
+// XOF is an identifier of an extendable-output function.
+type XOF uint
+
+const (
+	// SHAKE128 is the SHAKE128 extendable-output function.
+	SHAKE128 XOF = iota + 1
+	// SHAKE256 is the SHAKE256 extendable-output function.
+	SHAKE256
+)
+
+func (x XOF) String() string {
+	switch x {
+	case SHAKE128:
+		return "SHAKE128"
+	case SHAKE256:
+		return "SHAKE256"
+	default:
+		return ""
+	}
+}
+
+// Available reports whether the given extendable-output function is supported.
+func (x XOF) Available() bool { return x == SHAKE128 || x == SHAKE256 }
+
+// New returns a new instance of the extendable-output function.
+func (x XOF) New() sha3.ShakeHash {
+	switch x {
+	case SHAKE128:
+		return sha3.NewShake128()
+	case SHAKE256:
+		return sha3.NewShake256()
+	default:
+		panic(ErrInvalidXOF)
+	}
+}
+
+// ExpandMessageXMD produces a uniformly random byte string of lenInBytes bytes
+// using a Merkle-Damgård hash function h, as defined by expand_message_xmd
+// in RFC 9380 (Section 5.3.1). A DST longer than 255 bytes is reduced as
+// specified in Section 5.3.3, and an empty DST is rejected.
+func ExpandMessageXMD(h crypto.Hash, msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if !h.Available() {
+		return nil, ErrInvalidHash
+	}
+	if len(dst) == 0 {
+		return nil, ErrEmptyDST
+	}
+	if len(dst) > maxDSTLength {
+		// DST = H("H2C-OVERSIZE-DST-" || a_very_long_DST)
+		H := h.New()
+		H.Write(oversizeDSTPrefix)
+		H.Write(dst)
+		dst = H.Sum(nil)
+	}
+	if lenInBytes < 0 || lenInBytes > maxExpandedLength {
+		return nil, ErrLongOutput
+	}
+	H := h.New()
+	bInBytes := H.Size()
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > maxXMDBlocks {
+		return nil, fmt.Errorf("%w: ell=%v > 255", ErrLongOutput, ell)
+	}
+
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst))) // DST || I2OSP(len(DST), 1)
+	zPad := make([]byte, H.BlockSize())                          // I2OSP(0, s_in_bytes)
+	libStr := []byte{byte(lenInBytes >> 8), byte(lenInBytes)}    // I2OSP(len_in_bytes, 2)
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	H.Write(zPad)
+	H.Write(msg)
+	H.Write(libStr)
+	H.Write([]byte{0})
+	H.Write(dstPrime)
+	b0 := H.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	H.Reset()
+	H.Write(b0)
+	H.Write([]byte{1})
+	H.Write(dstPrime)
+	bi := H.Sum(nil)
+
+	uniform := make([]byte, 0, ell*bInBytes)
+	uniform = append(uniform, bi...)
+	for i := 2; i <= ell; i++ {
+		// b_i = H(strxor(b_0, b_(i-1)) || I2OSP(i, 1) || DST_prime)
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		H.Reset()
+		H.Write(bi)
+		H.Write([]byte{byte(i)})
+		H.Write(dstPrime)
+		bi = H.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:lenInBytes], nil
+}
+
+// ExpandMessageXOF produces a uniformly random byte string of lenInBytes bytes
+// using an extendable-output function x, as defined by expand_message_xof in
+// RFC 9380 (Section 5.3.2). The target security level k (in bits) determines
+// the length of the reduced DST when the DST is longer than 255 bytes.
+func ExpandMessageXOF(x XOF, k uint, msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if !x.Available() {
+		return nil, ErrInvalidXOF
+	}
+	if len(dst) == 0 {
+		return nil, ErrEmptyDST
+	}
+	if len(dst) > maxDSTLength {
+		// DST = H("H2C-OVERSIZE-DST-" || a_very_long_DST, ceil(2 * k / 8))
+		H := x.New()
+		H.Write(oversizeDSTPrefix)
+		H.Write(dst)
+		dst = make([]byte, (2*k+7)/8)
+		if _, err := io.ReadFull(H, dst); err != nil {
+			return nil, err
+		}
+	}
+	if lenInBytes < 0 || lenInBytes > maxExpandedLength {
+		return nil, ErrLongOutput
+	}
+
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst))) // DST || I2OSP(len(DST), 1)
+	libStr := []byte{byte(lenInBytes >> 8), byte(lenInBytes)}    // I2OSP(len_in_bytes, 2)
+
+	// uniform_bytes = H(msg || I2OSP(len_in_bytes, 2) || DST_prime, len_in_bytes)
+	H := x.New()
+	H.Write(msg)
+	H.Write(libStr)
+	H.Write(dstPrime)
+	uniform := make([]byte, lenInBytes)
+	if _, err := io.ReadFull(H, uniform); err != nil {
+		return nil, err
+	}
+	return uniform, nil
+}
+
+// expander is a function that expands a message into a byte string.
+type expander interface {
+	expand(msg, dst []byte, lenInBytes int) ([]byte, error)
+}
+
+type xmd struct{ crypto.Hash }
+
+func (e xmd) expand(msg, dst []byte, n int) ([]byte, error) {
+	return ExpandMessageXMD(e.Hash, msg, dst, n)
+}
+
+type xof struct {
+	XOF
+	k uint
+}
+
+func (e xof) expand(msg, dst []byte, n int) ([]byte, error) {
+	return ExpandMessageXOF(e.XOF, e.k, msg, dst, n)
+}
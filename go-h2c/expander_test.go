@@ -0,0 +1,129 @@
+package h2c_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	h2c "github.com/armfazh/hash-to-curve-ref/go-h2c"
+)
+
+type vectorExpander struct {
+	DST   string `json:"DST"`
+	Hash  string `json:"hash"`
+	K     uint   `json:"k"`
+	Name  string `json:"name"`
+	Tests []struct {
+		DstPrime     string `json:"DST_prime"`
+		Len          string `json:"len_in_bytes"`
+		Msg          string `json:"msg"`
+		MsgPrime     string `json:"msg_prime"`
+		UniformBytes string `json:"uniform_bytes"`
+	} `json:"tests"`
+}
+
+func (v vectorExpander) test(t *testing.T) {
+	var expand func(msg, dst []byte, lenInBytes int) ([]byte, error)
+	switch v.Hash {
+	case "SHA256":
+		expand = func(msg, dst []byte, n int) ([]byte, error) { return h2c.ExpandMessageXMD(crypto.SHA256, msg, dst, n) }
+	case "SHA512":
+		expand = func(msg, dst []byte, n int) ([]byte, error) { return h2c.ExpandMessageXMD(crypto.SHA512, msg, dst, n) }
+	case "SHAKE128":
+		expand = func(msg, dst []byte, n int) ([]byte, error) {
+			return h2c.ExpandMessageXOF(h2c.SHAKE128, v.K, msg, dst, n)
+		}
+	case "SHAKE256":
+		expand = func(msg, dst []byte, n int) ([]byte, error) {
+			return h2c.ExpandMessageXOF(h2c.SHAKE256, v.K, msg, dst, n)
+		}
+	default:
+		t.Skipf("hash: %v not supported", v.Hash)
+	}
+	for i := range v.Tests {
+		lenInBytes, err := strconv.ParseUint(v.Tests[i].Len, 0, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := expand([]byte(v.Tests[i].Msg), []byte(v.DST), int(lenInBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := hex.DecodeString(v.Tests[i].UniformBytes)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%v-%v\ngot:  %x\nwant: %x", v.Name, v.Hash, got, want)
+		}
+	}
+}
+
+func TestExpander(t *testing.T) {
+	if errFolder := filepath.Walk("testdata/expander",
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			jsonFile, errFile := os.Open(path)
+			if errFile != nil {
+				return errFile
+			}
+			defer jsonFile.Close()
+
+			byteValue, errRead := ioutil.ReadAll(jsonFile)
+			if errRead != nil {
+				return errRead
+			}
+			var v vectorExpander
+			errJSON := json.Unmarshal(byteValue, &v)
+			if errJSON != nil {
+				return errJSON
+			}
+			t.Run(filepath.Base(path), v.test)
+			return nil
+		}); errFolder != nil {
+		t.Fatalf("error on reading testdata folder: %v", errFolder)
+	}
+}
+
+func TestExpanderErrors(t *testing.T) {
+	msg := []byte("msg")
+	dst := []byte("QUUX-V01-CS02-with-expander")
+	for _, tt := range []struct {
+		Name       string
+		H          crypto.Hash
+		Dst        []byte
+		LenInBytes int
+	}{
+		{"ell>255", crypto.SHA256, dst, 255*32 + 1},
+		{"len>65535", crypto.SHA512, dst, 65536},
+		{"negativeLen", crypto.SHA256, dst, -1},
+		{"emptyDST", crypto.SHA256, nil, 32},
+	} {
+		if _, err := h2c.ExpandMessageXMD(tt.H, msg, tt.Dst, tt.LenInBytes); err == nil {
+			t.Errorf("%v: expected an error", tt.Name)
+		}
+	}
+	for _, tt := range []struct {
+		Name       string
+		X          h2c.XOF
+		Dst        []byte
+		LenInBytes int
+	}{
+		{"len>65535", h2c.SHAKE128, dst, 65536},
+		{"negativeLen", h2c.SHAKE256, dst, -1},
+		{"emptyDST", h2c.SHAKE256, []byte{}, 32},
+		{"invalidXOF", h2c.XOF(0), dst, 32},
+	} {
+		if _, err := h2c.ExpandMessageXOF(tt.X, 128, msg, tt.Dst, tt.LenInBytes); err == nil {
+			t.Errorf("%v: expected an error", tt.Name)
+		}
+	}
+}
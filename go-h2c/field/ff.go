@@ -2,6 +2,7 @@
 package field
 
 import (
+	"errors"
 	"io"
 	"math/big"
 )
@@ -14,11 +15,12 @@ type Elt interface {
 // Field describes the operations required to implement a finite field.
 type Field interface {
 	// Constructing elements
-	Zero() Elt            // Returns the Zero element.
-	One() Elt             // Returns the One element.
-	Elt(interface{}) Elt  // Constructor of elements from an int, uint, or string.
-	Rand(r io.Reader) Elt // Returns an elements chosen at random.
-	Generator() Elt       // Returns an additive generator.
+	Zero() Elt             // Returns the Zero element.
+	One() Elt              // Returns the One element.
+	Elt(interface{}) Elt   // Constructor of elements from an int, uint, or string.
+	Rand(r io.Reader) Elt  // Returns an elements chosen at random.
+	Coeffs(Elt) []*big.Int // Returns the coefficients of an element as integers in [0,p).
+	Generator() Elt        // Returns an additive generator.
 	// Properties
 	P() *big.Int     // Characteristic of the field.
 	Order() *big.Int // Size of the field.
@@ -45,6 +47,22 @@ type Field interface {
 
 type hasSqrt interface{ Sqrt(Elt) Elt }
 
+// Errors returned by the constructors of fields.
+var (
+	// ErrNotPrime is returned when the modulus is not an odd prime.
+	ErrNotPrime = errors.New("field: modulus is not an odd prime")
+	// ErrNotIrreducible is returned when the polynomial defining an
+	// extension field is not irreducible.
+	ErrNotIrreducible = errors.New("field: polynomial is not irreducible")
+	// ErrUnsupported is returned for fields with no implementation.
+	ErrUnsupported = errors.New("field: field not supported")
+	// ErrInvalidType is returned when a value cannot be converted to an integer.
+	ErrInvalidType = errors.New("field: value cannot be converted to an integer")
+	// ErrSquare is returned when an element required to be a non-square is
+	// a square.
+	ErrSquare = errors.New("field: element is a square")
+)
+
 // Sgn0ID is an identifier of a sign function.
 type Sgn0ID int
 
@@ -25,30 +25,34 @@ type fp struct {
 }
 
 // NewFp creates a prime field as Z/pZ given p as an int, uint, *big.Int or string.
-func NewFp(id ID, p interface{}) Field {
-	prime := FromType(p)
-	if !prime.ProbablyPrime(4) {
-		panic(fmt.Errorf("Modulus is not prime p:%v", prime))
+// It panics if p is not an odd prime, see NewFpE.
+func NewFp(id ID, p interface{}) Field { return mustField(NewFpE(id, p)) }
+
+// NewFpE creates a prime field as Z/pZ given p as an int, uint, *big.Int or
+// string. It returns ErrNotPrime if p is not an odd prime.
+func NewFpE(id ID, p interface{}) (Field, error) {
+	prime, err := FromTypeE(p)
+	if err != nil {
+		return nil, err
+	}
+	if !prime.ProbablyPrime(4) || prime.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w: p:%v", ErrNotPrime, prime)
 	}
 	f := fp{p: prime, id: id}
-	f.precmp()
-	return f
+	if err := f.precmp(); err != nil {
+		return nil, err
+	}
+	return f, nil
 }
 
-func (f *fp) precmp() {
-	t := big.NewInt(16)
-	pMod16 := t.Mod(f.p, t).Uint64()
-	switch {
-	case pMod16%4 == uint64(3):
-		f.hasSqrt = generateSqrt3mod4(f)
-	case pMod16%8 == uint64(5):
-		f.hasSqrt = generateSqrt5mod8(f)
-	case pMod16%16 == uint64(9):
-		f.hasSqrt = generateSqrt9mod16(f)
-	case pMod16%16 == uint64(1):
-		f.hasSqrt = generateSqrt1mod16(f)
+func mustField(f Field, err error) Field {
+	if err != nil {
+		panic(err)
 	}
+	return f
+}
 
+func (f *fp) precmp() (err error) {
 	pMinus1div2 := big.NewInt(1)
 	pMinus1div2.Sub(f.p, pMinus1div2)
 	pMinus1div2.Rsh(pMinus1div2, 1)
@@ -57,16 +61,24 @@ func (f *fp) precmp() {
 	pMinus2.Sub(f.p, pMinus2)
 	f.cte.pMinus1div2 = pMinus1div2
 	f.cte.pMinus2 = pMinus2
+	f.hasSqrt, err = generateSqrt(f)
+	return err
 }
 
-func (f fp) String() string       { return fmt.Sprintf("GF(%v)", f.id) }
-func (f fp) Zero() Elt            { return &fpElt{big.NewInt(0)} }
-func (f fp) One() Elt             { return &fpElt{big.NewInt(1)} }
-func (f fp) Rand(r io.Reader) Elt { e, _ := rand.Int(r, f.p); return &fpElt{e} }
-func (f fp) P() *big.Int          { return new(big.Int).Set(f.p) }
-func (f fp) Order() *big.Int      { return new(big.Int).Set(f.p) }
-func (f fp) Ext() uint            { return uint(1) }
-func (f fp) BitLen() int          { return f.p.BitLen() }
+func (f fp) String() string {
+	if f.id.String() == "" {
+		return fmt.Sprintf("GF(%v)", f.p)
+	}
+	return fmt.Sprintf("GF(%v)", f.id)
+}
+func (f fp) Zero() Elt               { return &fpElt{big.NewInt(0)} }
+func (f fp) One() Elt                { return &fpElt{big.NewInt(1)} }
+func (f fp) Rand(r io.Reader) Elt    { e, _ := rand.Int(r, f.p); return &fpElt{e} }
+func (f fp) Coeffs(x Elt) []*big.Int { return []*big.Int{new(big.Int).Set(x.(*fpElt).n)} }
+func (f fp) P() *big.Int             { return new(big.Int).Set(f.p) }
+func (f fp) Order() *big.Int         { return new(big.Int).Set(f.p) }
+func (f fp) Ext() uint               { return uint(1) }
+func (f fp) BitLen() int             { return f.p.BitLen() }
 func (f fp) Elt(in interface{}) Elt {
 	var n *big.Int
 	if v, ok := in.([]interface{}); ok && len(v) == 1 {
@@ -82,8 +94,15 @@ func (f fp) mod(x *big.Int) Elt { return &fpElt{x.Mod(x, f.p)} }
 
 func (f fp) IsZero(x Elt) bool      { return x.(*fpElt).n.Sign() == 0 }
 func (f fp) AreEqual(x, y Elt) bool { return f.IsZero(f.Sub(x, y)) }
-func (f fp) IsSquare(x Elt) bool    { return f.AreEqual(f.Exp(x, f.cte.pMinus1div2), f.One()) }
-func (f fp) IsEqual(ff Field) bool  { return f.p.Cmp(ff.(fp).p) == 0 }
+func (f fp) IsSquare(x Elt) bool {
+	// x is a square if x^((p-1)/2) is either 0 or 1.
+	t := f.Exp(x, f.cte.pMinus1div2)
+	return f.IsZero(t) || f.AreEqual(t, f.One())
+}
+func (f fp) IsEqual(ff Field) bool {
+	g, ok := ff.(fp)
+	return ok && f.p.Cmp(g.p) == 0
+}
 
 // Implementing hasArith
 
@@ -122,31 +141,31 @@ func (f fp) CMov(x, y Elt, b bool) Elt {
 }
 
 type sqrt3mod4 struct {
-	*fp
+	Field
 	exp *big.Int
 }
 
-func generateSqrt3mod4(f *fp) hasSqrt {
+func generateSqrt3mod4(f Field) hasSqrt {
 	e := big.NewInt(1)
-	e.Add(f.p, e)
+	e.Add(f.P(), e)
 	e.Rsh(e, 2)
-	return sqrt3mod4{exp: e, fp: f}
+	return sqrt3mod4{exp: e, Field: f}
 }
 
 func (s sqrt3mod4) Sqrt(x Elt) Elt { return s.Exp(x, s.exp) }
 
 type sqrt5mod8 struct {
-	*fp
-	sqrtOne *fpElt
+	Field
+	sqrtOne Elt
 	exp     *big.Int
 }
 
-func generateSqrt5mod8(f *fp) hasSqrt {
+func generateSqrt5mod8(f Field) hasSqrt {
 	// calculates s = sqrt(-1) for p=8*k+5
 	// t = 2^k
 	// s = 2*t^3+t
 	k := big.NewInt(5)
-	k.Sub(f.p, k)           // p-5
+	k.Sub(f.P(), k)         // p-5
 	k.Rsh(k, 3)             // k = (p-5)/8
 	t := f.Exp(f.Elt(2), k) // t = 2^k
 	s := f.Sqr(t)           // t^2
@@ -154,7 +173,7 @@ func generateSqrt5mod8(f *fp) hasSqrt {
 	s = f.Add(s, f.One())   // 2t^2+1
 	s = f.Mul(s, t)         // t(2t^2+1)
 	k.Add(k, big.NewInt(1)) // e = k+1 = (p+3)/8
-	return sqrt5mod8{fp: f, exp: k, sqrtOne: s.(*fpElt)}
+	return sqrt5mod8{Field: f, exp: k, sqrtOne: s}
 }
 
 func (s sqrt5mod8) Sqrt(x Elt) Elt {
@@ -165,5 +184,117 @@ func (s sqrt5mod8) Sqrt(x Elt) Elt {
 	return s.CMov(t1, t0, e)
 }
 
-func generateSqrt9mod16(f *fp) hasSqrt { panic("not implemented yet") }
-func generateSqrt1mod16(f *fp) hasSqrt { panic("not implemented yet") }
+type sqrt9mod16 struct {
+	Field
+	c1, c2, c3 Elt
+	c4         *big.Int
+}
+
+func generateSqrt9mod16(f Field) (hasSqrt, error) {
+	// c1 = sqrt(-1), c2 = sqrt(c1), c3 = sqrt(-c1), c4 = (q+7)/16
+	ts, err := generateSqrt1mod16(f)
+	if err != nil {
+		return nil, err
+	}
+	c1 := ts.Sqrt(f.Elt(-1))
+	c2 := ts.Sqrt(c1)
+	c3 := ts.Sqrt(f.Neg(c1))
+	c4 := big.NewInt(7)
+	c4.Add(f.Order(), c4)
+	c4.Rsh(c4, 4)
+	return sqrt9mod16{Field: f, c1: c1, c2: c2, c3: c3, c4: c4}, nil
+}
+
+// Sqrt returns a square root of x as specified in RFC 9380 (Appendix I.3).
+func (s sqrt9mod16) Sqrt(x Elt) Elt {
+	tv1 := s.Exp(x, s.c4)           // 1. tv1 = x^c4
+	tv2 := s.Mul(s.c1, tv1)         // 2. tv2 = c1 * tv1
+	tv3 := s.Mul(s.c2, tv1)         // 3. tv3 = c2 * tv1
+	tv4 := s.Mul(s.c3, tv1)         // 4. tv4 = c3 * tv1
+	e1 := s.AreEqual(s.Sqr(tv2), x) // 5.  e1 = (tv2^2) == x
+	e2 := s.AreEqual(s.Sqr(tv3), x) // 6.  e2 = (tv3^2) == x
+	tv1 = s.CMov(tv1, tv2, e1)      // 7. tv1 = CMOV(tv1, tv2, e1)
+	tv2 = s.CMov(tv4, tv3, e2)      // 8. tv2 = CMOV(tv4, tv3, e2)
+	e3 := s.AreEqual(s.Sqr(tv2), x) // 9.  e3 = (tv2^2) == x
+	return s.CMov(tv1, tv2, e3)     // 10.  z = CMOV(tv1, tv2, e3)
+}
+
+type sqrtTonelliShanks struct {
+	Field
+	c1 int
+	c3 *big.Int
+	c5 Elt
+}
+
+func generateSqrt1mod16(f Field) (hasSqrt, error) {
+	// c1 is the largest integer such that 2^c1 divides q-1
+	// c2 = (q-1)/(2^c1)
+	// c3 = (c2-1)/2
+	// c4 is a non-square value in F
+	// c5 = c4^c2
+	qMinus1 := new(big.Int).Sub(f.Order(), big.NewInt(1))
+	c1 := int(qMinus1.TrailingZeroBits())
+	c2 := new(big.Int).Rsh(qMinus1, uint(c1))
+	c3 := new(big.Int).Rsh(c2, 1)
+	c4, err := findNonSquare(f)
+	if err != nil {
+		return nil, err
+	}
+	c5 := f.Exp(c4, c2)
+	return sqrtTonelliShanks{Field: f, c1: c1, c3: c3, c5: c5}, nil
+}
+
+// findNonSquare returns the smallest non-square of f greater than one. It
+// tries every candidate below p, so it fails if p is not an odd prime.
+func findNonSquare(f Field) (Elt, error) {
+	p := f.P()
+	for c := big.NewInt(2); c.Cmp(p) < 0; c.Add(c, big.NewInt(1)) {
+		if x := f.Elt(new(big.Int).Set(c)); !f.IsSquare(x) {
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no non-square in GF(%v)", ErrNotPrime, p)
+}
+
+// Sqrt returns a square root of x using the constant-time Tonelli-Shanks
+// algorithm as specified in RFC 9380 (Appendix I.4).
+func (s sqrtTonelliShanks) Sqrt(x Elt) Elt {
+	z := s.Exp(x, s.c3) // 1. z = x^c3
+	t := s.Sqr(z)       // 2. t = z * z
+	t = s.Mul(t, x)     // 3. t = t * x
+	z = s.Mul(z, x)     // 4. z = z * x
+	b := t              // 5. b = t
+	c := s.c5           // 6. c = c5
+	for i := s.c1; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b = s.Sqr(b) // 9. b = b * b
+		}
+		e := s.AreEqual(b, s.One()) // 10.  e = b == 1
+		zt := s.Mul(z, c)           // 11. zt = z * c
+		z = s.CMov(zt, z, e)        // 12.  z = CMOV(zt, z, e)
+		c = s.Sqr(c)                // 13.  c = c * c
+		tt := s.Mul(t, c)           // 14. tt = t * c
+		t = s.CMov(tt, t, e)        // 15.  t = CMOV(tt, t, e)
+		b = t                       // 16.  b = t
+	}
+	return z
+}
+
+// generateSqrt returns a square root algorithm for the prime field f. It
+// returns ErrNotPrime if p is even.
+func generateSqrt(f Field) (hasSqrt, error) {
+	t := big.NewInt(16)
+	pMod16 := t.Mod(f.P(), t).Uint64()
+	switch {
+	case pMod16%2 == uint64(0):
+		return nil, fmt.Errorf("%w: p:%v", ErrNotPrime, f.P())
+	case pMod16%4 == uint64(3):
+		return generateSqrt3mod4(f), nil
+	case pMod16%8 == uint64(5):
+		return generateSqrt5mod8(f), nil
+	case pMod16%16 == uint64(9):
+		return generateSqrt9mod16(f)
+	default:
+		return generateSqrt1mod16(f)
+	}
+}
@@ -2,10 +2,12 @@ package field
 
 import (
 	"crypto/rand"
+	"fmt"
 	"io"
 	"math/big"
 )
 
+// fp2Elt is an element a+b*i of a quadratic extension field.
 type fp2Elt struct {
 	a, b *big.Int
 }
@@ -15,23 +17,57 @@ func (e fp2Elt) String() string {
 		"\nb: 0x" + e.b.Text(16) + " * i"
 }
 
-func (e fp2Elt) Copy() Elt { r := &fp2Elt{}; r.a.Set(e.a); r.b.Set(e.b); return r }
+func (e fp2Elt) Copy() Elt { return &fp2Elt{new(big.Int).Set(e.a), new(big.Int).Set(e.b)} }
 
+// fp2 implements a quadratic extension field.
 type fp2 struct {
 	p    *big.Int
 	name string
+	base fp
 	cte  struct {
 		pMinus1div2 *big.Int
 	}
+	hasSqrt
 }
 
 // NewFp2 creates a quadratic extension field Z/pZ[x] with irreducible polynomial x^2=-1 and given p as an int, uint, *big.Int or string.
-func NewFp2(name string, p interface{}) Field {
-	prime := FromType(p)
+// The polynomial is irreducible only if p=3 mod 4.
+// It panics if the field cannot be created, see NewFp2E.
+func NewFp2(name string, p interface{}) Field { return mustField(NewFp2E(name, p)) }
+
+// NewFp2E is like NewFp2 but it returns ErrNotPrime if p is not prime, or
+// ErrNotIrreducible if p != 3 mod 4.
+func NewFp2E(name string, p interface{}) (Field, error) {
+	prime, err := FromTypeE(p)
+	if err != nil {
+		return nil, err
+	}
 	if !prime.ProbablyPrime(4) {
-		panic("p is not prime")
+		return nil, fmt.Errorf("%w: p:%v", ErrNotPrime, prime)
+	}
+	if prime.Bit(0) != 1 || prime.Bit(1) != 1 {
+		return nil, fmt.Errorf("%w: x^2+1 for p:%v", ErrNotIrreducible, prime)
+	}
+	f := fp2{p: prime, name: name, base: NewFp(0, prime).(fp)}
+	f.precmp()
+	return f, nil
+}
+
+func (f *fp2) precmp() {
+	pMinus1div2 := big.NewInt(1)
+	pMinus1div2.Sub(f.p, pMinus1div2)
+	pMinus1div2.Rsh(pMinus1div2, 1)
+	f.cte.pMinus1div2 = pMinus1div2
+
+	// Since p=3 mod 4, q=p^2 is either 9 mod 16 or 1 mod 16.
+	t := big.NewInt(16)
+	qMod16 := t.Mod(f.Order(), t).Uint64()
+	switch qMod16 {
+	case uint64(9):
+		f.hasSqrt = generateSqrt9mod16Fp2(f)
+	default:
+		f.hasSqrt = sqrtComplex{f}
 	}
-	return fp2{p: prime, name: name}
 }
 
 func (f fp2) Elt(in interface{}) Elt {
@@ -39,6 +75,9 @@ func (f fp2) Elt(in interface{}) Elt {
 	if v, ok := in.([]interface{}); ok && len(v) == 2 {
 		a = FromType(v[0])
 		b = FromType(v[1])
+	} else if ok && len(v) == 1 {
+		a = FromType(v[0])
+		b = big.NewInt(0)
 	} else {
 		a = FromType(in)
 		b = big.NewInt(0)
@@ -52,15 +91,25 @@ func (f fp2) Ext() uint       { return uint(2) }
 func (f fp2) Zero() Elt       { return f.Elt(0) }
 func (f fp2) One() Elt        { return f.Elt(1) }
 func (f fp2) BitLen() int     { return f.p.BitLen() }
+func (f fp2) Coeffs(x Elt) []*big.Int {
+	e := x.(*fp2Elt)
+	return []*big.Int{new(big.Int).Set(e.a), new(big.Int).Set(e.b)}
+}
 
 func (f fp2) AreEqual(x, y Elt) bool { return f.IsZero(f.Sub(x, y)) }
-func (f fp2) IsEqual(ff Field) bool  { return f.p.Cmp(ff.(fp2).p) == 0 }
+func (f fp2) IsEqual(ff Field) bool {
+	g, ok := ff.(fp2)
+	return ok && f.p.Cmp(g.p) == 0
+}
 func (f fp2) IsZero(x Elt) bool {
 	e := x.(*fp2Elt)
-	return e.a.Mod(e.a, f.p).Sign() == 0 &&
-		e.b.Mod(e.b, f.p).Sign() == 0
+	return e.a.Sign() == 0 && e.b.Sign() == 0
 }
 
+// IsSquare returns true if x is a quadratic residue, which holds if and only
+// if its norm a^2+b^2 is a quadratic residue in the base field.
+func (f fp2) IsSquare(x Elt) bool { return f.base.IsSquare(f.norm(x)) }
+
 func (f fp2) Rand(r io.Reader) Elt {
 	a, _ := rand.Int(r, f.p)
 	b, _ := rand.Int(r, f.p)
@@ -68,30 +117,71 @@ func (f fp2) Rand(r io.Reader) Elt {
 }
 
 func (f fp2) mod(a, b *big.Int) Elt { return &fp2Elt{a: a.Mod(a, f.p), b: b.Mod(b, f.p)} }
+
+// Implementing hasArith
+
+func (f fp2) Neg(x Elt) Elt {
+	a := new(big.Int).Neg(x.(*fp2Elt).a)
+	b := new(big.Int).Neg(x.(*fp2Elt).b)
+	return f.mod(a, b)
+}
 func (f fp2) Add(x, y Elt) Elt {
-	a := new(big.Int).Add(x.(fp2Elt).a, y.(fp2Elt).a)
-	b := new(big.Int).Add(x.(fp2Elt).b, y.(fp2Elt).b)
-	a.Mod(a, f.p)
-	b.Mod(b, f.p)
-	return fp2Elt{a, b}
+	a := new(big.Int).Add(x.(*fp2Elt).a, y.(*fp2Elt).a)
+	b := new(big.Int).Add(x.(*fp2Elt).b, y.(*fp2Elt).b)
+	return f.mod(a, b)
 }
 func (f fp2) Sub(x, y Elt) Elt {
-	a := new(big.Int).Sub(x.(fp2Elt).a, y.(fp2Elt).a)
-	b := new(big.Int).Sub(x.(fp2Elt).b, y.(fp2Elt).b)
-	a.Mod(a, f.p)
-	b.Mod(b, f.p)
-	return fp2Elt{a, b}
-}
-
-func (f fp2) Mul(x, y Elt) Elt          { return nil }
-func (f fp2) Sqr(x Elt) Elt             { return nil }
-func (f fp2) Inv(x Elt) Elt             { return nil }
-func (f fp2) Neg(x Elt) Elt             { return nil }
-func (f fp2) Sqrt(x Elt) Elt            { return nil }
-func (f fp2) Exp(x Elt, e *big.Int) Elt { return nil }
-func (f fp2) IsSquare(x Elt) bool       { return false }
-
-func (f fp2) Generator() Elt { return f.Elt([]string{"0", "1"}) }
+	a := new(big.Int).Sub(x.(*fp2Elt).a, y.(*fp2Elt).a)
+	b := new(big.Int).Sub(x.(*fp2Elt).b, y.(*fp2Elt).b)
+	return f.mod(a, b)
+}
+func (f fp2) Mul(x, y Elt) Elt {
+	// (a0+b0*i)(a1+b1*i) = (a0*a1-b0*b1) + (a0*b1+b0*a1)*i
+	x0, x1 := x.(*fp2Elt).a, x.(*fp2Elt).b
+	y0, y1 := y.(*fp2Elt).a, y.(*fp2Elt).b
+	a := new(big.Int).Mul(x0, y0)
+	a.Sub(a, new(big.Int).Mul(x1, y1))
+	b := new(big.Int).Mul(x0, y1)
+	b.Add(b, new(big.Int).Mul(x1, y0))
+	return f.mod(a, b)
+}
+func (f fp2) Sqr(x Elt) Elt {
+	// (a+b*i)^2 = (a+b)(a-b) + 2ab*i
+	x0, x1 := x.(*fp2Elt).a, x.(*fp2Elt).b
+	a := new(big.Int).Add(x0, x1)
+	a.Mul(a, new(big.Int).Sub(x0, x1))
+	b := new(big.Int).Mul(x0, x1)
+	b.Lsh(b, 1)
+	return f.mod(a, b)
+}
+func (f fp2) Inv(x Elt) Elt {
+	// 1/(a+b*i) = (a-b*i)/(a^2+b^2)
+	n := f.base.Inv(f.norm(x)).(*fpElt).n
+	a := new(big.Int).Mul(x.(*fp2Elt).a, n)
+	b := new(big.Int).Mul(x.(*fp2Elt).b, n)
+	return f.mod(a, b.Neg(b))
+}
+func (f fp2) Exp(x Elt, e *big.Int) Elt {
+	z := f.One()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		z = f.Sqr(z)
+		if e.Bit(i) == 1 {
+			z = f.Mul(z, x)
+		}
+	}
+	return z
+}
+
+// norm returns a^2+b^2 as an element of the base field.
+func (f fp2) norm(x Elt) Elt {
+	a := new(big.Int).Mul(x.(*fp2Elt).a, x.(*fp2Elt).a)
+	b := new(big.Int).Mul(x.(*fp2Elt).b, x.(*fp2Elt).b)
+	return f.base.mod(a.Add(a, b))
+}
+
+// Implementing extended operations
+
+func (f fp2) Generator() Elt { return f.Elt([]interface{}{0, 1}) }
 func (f fp2) Inv0(x Elt) Elt { return f.Inv(x) }
 func (f fp2) CMov(x, y Elt, b bool) Elt {
 	var za, zb big.Int
@@ -113,20 +203,101 @@ func (f fp2) GetSgn0(id Sgn0ID) func(Elt) int {
 	}
 	panic("Wrong signID")
 }
+
+// Sgn0BE returns the sign of the most significant non-zero coefficient,
+// where a coefficient greater than (p-1)/2 is negative.
 func (f fp2) Sgn0BE(x Elt) int {
-	/* [TODO] */
-	sb := x.(*fp2Elt).b.Sign()
-	cb := 2*(f.cte.pMinus1div2.Cmp(x.(*fp2Elt).b)&^1) - 1
-	sa := x.(*fp2Elt).a.Sign()
-	ca := 2*(f.cte.pMinus1div2.Cmp(x.(*fp2Elt).a)&^1) - 1
-	return sb*cb + (1-sb)*(sa*ca+(1-sa)*1)
+	sign := func(v *big.Int) int {
+		if v.Sign() == 0 {
+			return 0
+		}
+		return f.base.Sgn0BE(&fpElt{v})
+	}
+	return sgn0Ext(sign, x.(*fp2Elt).b, x.(*fp2Elt).a)
 }
 
+// Sgn0LE returns the sign of the least significant non-zero coefficient,
+// where an odd coefficient is negative (RFC 9380, Section 4.1).
 func (f fp2) Sgn0LE(x Elt) int {
-	/* [TODO] */
-	sa := x.(*fp2Elt).a.Sign()
-	ca := 1 - 2*int(x.(*fp2Elt).a.Bit(0))
-	sb := x.(*fp2Elt).b.Sign()
-	cb := 1 - 2*int(x.(*fp2Elt).b.Bit(0))
-	return sa*ca + (1-sa)*(sb*cb+(1-sb)*1)
+	sign := func(v *big.Int) int {
+		if v.Sign() == 0 {
+			return 0
+		}
+		return f.base.Sgn0LE(&fpElt{v})
+	}
+	return sgn0Ext(sign, x.(*fp2Elt).a, x.(*fp2Elt).b)
+}
+
+// sgn0Ext returns the sign of the first non-zero coefficient, or 1 if all
+// coefficients are zero.
+func sgn0Ext(sign func(*big.Int) int, coeffs ...*big.Int) int {
+	s := 0
+	for _, c := range coeffs {
+		if s == 0 {
+			s = sign(c)
+		}
+	}
+	if s == 0 {
+		return 1
+	}
+	return s
+}
+
+type sqrt9mod16Fp2 struct {
+	*fp2
+	c1, c2, c3 Elt
+	c4         *big.Int
+}
+
+func generateSqrt9mod16Fp2(f *fp2) hasSqrt {
+	// c1 = sqrt(-1), c2 = sqrt(c1), c3 = sqrt(-c1), c4 = (q+7)/16
+	s := sqrtComplex{f}
+	c1 := f.Generator()
+	c2 := s.Sqrt(c1)
+	c3 := s.Sqrt(f.Neg(c1))
+	c4 := big.NewInt(7)
+	c4.Add(f.Order(), c4)
+	c4.Rsh(c4, 4)
+	return sqrt9mod16Fp2{fp2: f, c1: c1, c2: c2, c3: c3, c4: c4}
+}
+
+// Sqrt returns a square root of x as specified in RFC 9380 (Appendix I.3).
+func (s sqrt9mod16Fp2) Sqrt(x Elt) Elt {
+	tv1 := s.Exp(x, s.c4)           // 1. tv1 = x^c4
+	tv2 := s.Mul(s.c1, tv1)         // 2. tv2 = c1 * tv1
+	tv3 := s.Mul(s.c2, tv1)         // 3. tv3 = c2 * tv1
+	tv4 := s.Mul(s.c3, tv1)         // 4. tv4 = c3 * tv1
+	e1 := s.AreEqual(s.Sqr(tv2), x) // 5.  e1 = (tv2^2) == x
+	e2 := s.AreEqual(s.Sqr(tv3), x) // 6.  e2 = (tv3^2) == x
+	tv1 = s.CMov(tv1, tv2, e1)      // 7. tv1 = CMOV(tv1, tv2, e1)
+	tv2 = s.CMov(tv4, tv3, e2)      // 8. tv2 = CMOV(tv4, tv3, e2)
+	e3 := s.AreEqual(s.Sqr(tv2), x) // 9.  e3 = (tv2^2) == x
+	return s.CMov(tv1, tv2, e3)     // 10.  z = CMOV(tv1, tv2, e3)
+}
+
+// sqrtComplex computes square roots in GF(p^2) using square roots in GF(p),
+// it works for any p=3 mod 4, but it is not constant time.
+type sqrtComplex struct{ *fp2 }
+
+func (s sqrtComplex) Sqrt(x Elt) Elt {
+	F := s.base
+	a := &fpElt{x.(*fp2Elt).a}
+	b := &fpElt{x.(*fp2Elt).b}
+	if F.IsZero(b) {
+		if F.IsZero(a) || F.IsSquare(a) {
+			return &fp2Elt{F.Sqrt(a).(*fpElt).n, big.NewInt(0)}
+		}
+		return &fp2Elt{big.NewInt(0), F.Sqrt(F.Neg(a)).(*fpElt).n}
+	}
+	// alpha = sqrt(a^2+b^2), delta = (a+alpha)/2 or (a-alpha)/2
+	alpha := F.Sqrt(s.norm(x))
+	half := F.Inv(F.Elt(2))
+	delta := F.Mul(F.Add(a, alpha), half)
+	if !F.IsSquare(delta) {
+		delta = F.Mul(F.Sub(a, alpha), half)
+	}
+	// x0 = sqrt(delta), x1 = b/(2*x0)
+	x0 := F.Sqrt(delta)
+	x1 := F.Mul(b, F.Inv(F.Add(x0, x0)))
+	return &fp2Elt{x0.(*fpElt).n, x1.(*fpElt).n}
 }
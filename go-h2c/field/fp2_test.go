@@ -0,0 +1,97 @@
+package field_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+const blsPrime = "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"
+
+func TestFp2Sqrt(t *testing.T) {
+	var primes = []int{
+		607, // 7 mod 8, q = 1 mod 16
+		619, // 3 mod 8, q = 9 mod 16
+	}
+	for _, p := range primes {
+		F := GF.NewFp2("", p)
+		for a := 0; a < p; a += 7 {
+			for b := 0; b < p; b += 5 {
+				x := F.Elt([]interface{}{a, b})
+				if F.IsSquare(x) {
+					y := F.Sqrt(x)
+					got := F.Sqr(y)
+					want := x
+					if !F.AreEqual(got, want) {
+						t.Fatalf("got: %v\nwant: %v\nF:%v", got, want, F)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFp2Arith(t *testing.T) {
+	F := GF.NewFp2("BLS12381", blsPrime)
+	for i := 0; i < 64; i++ {
+		x := F.Rand(rand.Reader)
+		y := F.Rand(rand.Reader)
+		if !F.AreEqual(F.Sqr(x), F.Mul(x, x)) {
+			t.Fatalf("Sqr(x) != x*x, x: %v", x)
+		}
+		if !F.IsZero(x) && !F.AreEqual(F.Mul(x, F.Inv(x)), F.One()) {
+			t.Fatalf("x*1/x != 1, x: %v", x)
+		}
+		if !F.IsZero(F.Add(x, F.Neg(x))) {
+			t.Fatalf("x+(-x) != 0, x: %v", x)
+		}
+		if !F.AreEqual(F.Sub(F.Add(x, y), y), x) {
+			t.Fatalf("(x+y)-y != x, x: %v", x)
+		}
+		if !F.AreEqual(F.Exp(x, F.Order()), x) {
+			t.Fatalf("x^q != x, x: %v", x)
+		}
+		z := F.Sqr(x)
+		if !F.IsSquare(z) || !F.AreEqual(F.Sqr(F.Sqrt(z)), z) {
+			t.Fatalf("sqrt(x^2)^2 != x^2, x: %v", x)
+		}
+		if !F.AreEqual(x.Copy(), x) {
+			t.Fatalf("copy(x) != x, x: %v", x)
+		}
+	}
+	i := F.Generator()
+	if !F.AreEqual(F.Sqr(i), F.Elt(-1)) {
+		t.Fatalf("i^2 != -1")
+	}
+}
+
+func TestFp2Sgn0(t *testing.T) {
+	F := GF.NewFp2("", 619)
+	le := F.GetSgn0(GF.SignLE)
+	be := F.GetSgn0(GF.SignBE)
+	for _, v := range []struct {
+		a, b   int
+		le, be int
+	}{
+		{0, 0, 1, 1},
+		{1, 0, -1, 1},
+		{2, 0, 1, 1},
+		{0, 1, -1, 1},
+		{0, 2, 1, 1},
+		{2, 1, 1, 1},
+		{1, 618, -1, -1},
+		{618, 0, 1, -1},
+		{0, 618, 1, -1},
+		{0, 309, -1, 1},
+		{0, 310, 1, -1},
+	} {
+		x := F.Elt([]interface{}{v.a, v.b})
+		if got := le(x); got != v.le {
+			t.Fatalf("Sgn0LE(%v) got: %v want: %v", x, got, v.le)
+		}
+		if got := be(x); got != v.be {
+			t.Fatalf("Sgn0BE(%v) got: %v want: %v", x, got, v.be)
+		}
+	}
+}
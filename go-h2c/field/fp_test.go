@@ -1,6 +1,9 @@
 package field_test
 
 import (
+	"crypto/rand"
+	"errors"
+	"math/big"
 	"testing"
 
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
@@ -10,16 +13,94 @@ func TestSqrt(t *testing.T) {
 	var primes = []int{
 		607, // 3 mod 4
 		613, // 5 mod 8
-		// 617, // 9 mod 16
-		// 641, // 1 mod 16
+		617, // 9 mod 16
+		641, // 1 mod 16
 	}
 	for _, p := range primes {
-		testSqrt(t, p)
+		testSqrt(t, GF.NewFp(GF.ID(p), p))
+		testSqrt(t, GF.NewFpCT(GF.ID(p), p))
+	}
+	// GF(2) has no non-square, so it must be rejected instead of searching
+	// for one.
+	if _, err := GF.NewFpE(GF.ID(2), 2); !errors.Is(err, GF.ErrNotPrime) {
+		t.Fatalf("expected an error on p=2: %v", err)
+	}
+}
+
+func TestSqrtRatio(t *testing.T) {
+	for _, p := range []int{607, 613, 617, 641} {
+		testSqrtRatio(t, GF.NewFp(GF.ID(p), p))
+		testSqrtRatio(t, GF.NewFpCT(GF.ID(p), p))
+	}
+	for _, p := range []int{607, 619} {
+		testSqrtRatio(t, GF.NewFp2("", p))
+	}
+}
+
+func testSqrtRatio(t *testing.T, F GF.Field) {
+	// Z is the first non-square found starting from the generator.
+	Z := F.Generator()
+	for F.IsSquare(Z) {
+		Z = F.Add(Z, F.One())
+	}
+	if _, err := GF.NewSqrtRatioE(F, F.Sqr(Z)); !errors.Is(err, GF.ErrSquare) {
+		t.Fatalf("expected an error on square Z: %v", err)
+	}
+	S := GF.NewSqrtRatio(F, Z)
+	for i := 0; i < 200; i++ {
+		u := F.Rand(rand.Reader)
+		v := F.Rand(rand.Reader)
+		if F.IsZero(v) {
+			continue
+		}
+		if i == 0 {
+			u = F.Zero()
+		}
+		isQR, y := S.SqrtRatio(u, v)
+		uv := F.Mul(u, F.Inv(v))
+		if isQR != F.IsSquare(uv) {
+			t.Fatalf("wrong isQR u: %v v: %v F:%v", u, v, F)
+		}
+		want := uv
+		if !isQR {
+			want = F.Mul(Z, uv)
+		}
+		if got := F.Sqr(y); !F.AreEqual(got, want) {
+			t.Fatalf("got: %v\nwant: %v\nF:%v", got, want, F)
+		}
 	}
 }
 
-func testSqrt(t *testing.T, p int) {
-	F := GF.NewFp(GF.ID(p), p)
+func TestSqrtLarge(t *testing.T) {
+	// p9 is the smallest prime larger than 2^255 such that p9 = 9 mod 16.
+	p9 := new(big.Int).Lsh(big.NewInt(1), 255)
+	p9.Add(p9, big.NewInt(9))
+	for !p9.ProbablyPrime(20) {
+		p9.Add(p9, big.NewInt(16))
+	}
+	for _, p := range []interface{}{
+		p9,
+		// Scalar field of BLS12-381, p = 1 mod 2^32.
+		"0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001",
+		// Scalar field of BN254, p = 1 mod 2^28.
+		"21888242871839275222246405745257275088548364400416034343698204186575808495617",
+		// Pallas base field, p = 1 mod 2^32.
+		"0x40000000000000000000000000000000224698fc094cf91b992d30ed00000001",
+	} {
+		for _, F := range []GF.Field{GF.NewFp(0, p), GF.NewFpCT(0, p)} {
+			for i := 0; i < 32; i++ {
+				x := F.Sqr(F.Rand(rand.Reader))
+				y := F.Sqrt(x)
+				if got, want := F.Sqr(y), x; !F.AreEqual(got, want) {
+					t.Fatalf("got: %v\nwant: %v\nF:%v", got, want, F)
+				}
+			}
+		}
+	}
+}
+
+func testSqrt(t *testing.T, F GF.Field) {
+	p := int(F.P().Int64())
 	for i := 0; i < p; i++ {
 		x := F.Elt(i)
 		if F.IsSquare(x) {
@@ -32,3 +113,25 @@ func testSqrt(t *testing.T, p int) {
 		}
 	}
 }
+
+func TestErrors(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"NewFpE", second(GF.NewFpE(0, 15)), GF.ErrNotPrime},
+		{"NewFpE", second(GF.NewFpE(0, 2)), GF.ErrNotPrime},
+		{"NewFpCTE", second(GF.NewFpCTE(GF.P256, 15)), GF.ErrNotPrime},
+		{"NewFpCTE", second(GF.NewFpCTE(GF.P256, 2)), GF.ErrNotPrime},
+		{"NewFp2E", second(GF.NewFp2E("", 5)), GF.ErrNotIrreducible},
+		{"TryGet", second(GF.ID(255).TryGet()), GF.ErrUnsupported},
+		{"FromTypeE", second(GF.FromTypeE("0xz")), GF.ErrInvalidType},
+	} {
+		if !errors.Is(v.err, v.want) {
+			t.Fatalf("%v: got: %v want: %v", v.name, v.err, v.want)
+		}
+	}
+}
+
+func second(_ interface{}, err error) error { return err }
@@ -0,0 +1,368 @@
+package field
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// fpCTElt is a prime field element represented in the Montgomery domain using
+// a fixed number of 64-bit limbs in little-endian order.
+type fpCTElt struct {
+	f *fpCT
+	v []uint64
+}
+
+func (e fpCTElt) String() string { return e.f.toBig(&e).Text(10) }
+func (e fpCTElt) Copy() Elt      { return &fpCTElt{e.f, append([]uint64(nil), e.v...)} }
+
+// fpCT implements a prime field with fixed-size limbs and Montgomery
+// multiplication. Its operations run in time independent of the values of the
+// elements, but not of the exponents given to Exp.
+type fpCT struct {
+	p   []uint64 // Modulus.
+	pp  uint64   // -1/p mod 2^64.
+	r2  []uint64 // R^2 mod p, where R=2^(64*len(p)).
+	one []uint64 // R mod p.
+	// mulFn, if not nil, replaces the generic Montgomery multiplication. If
+	// mont is false, elements are not in the Montgomery domain, i.e., R=1.
+	mulFn func(z, x, y []uint64)
+	mont  bool
+	big   *big.Int
+	half  []uint64 // (p-1)/2.
+	id    ID
+	cte   struct {
+		pMinus1div2 *big.Int
+		pMinus2     *big.Int
+	}
+	hasSqrt
+}
+
+// NewFpCT creates a prime field as Z/pZ given p as an int, uint, *big.Int or
+// string. Unlike NewFp, the arithmetic runs in constant time. It panics if p
+// is not an odd prime, see NewFpCTE.
+func NewFpCT(id ID, p interface{}) Field { return mustField(NewFpCTE(id, p)) }
+
+// NewFpCTE is like NewFpCT but it returns ErrNotPrime if p is not an odd prime.
+func NewFpCTE(id ID, p interface{}) (Field, error) {
+	prime, err := FromTypeE(p)
+	if err != nil {
+		return nil, err
+	}
+	if !prime.ProbablyPrime(4) || prime.Bit(0) == 0 {
+		return nil, fmt.Errorf("%w: p:%v", ErrNotPrime, prime)
+	}
+	f := &fpCT{big: prime, id: id, mont: true}
+	if err := f.precmp(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// newFpSpecialized creates a prime field for the well-known prime identified
+// by id using the arithmetic generated for it.
+func newFpSpecialized(id ID, p interface{}) (Field, error) {
+	a, ok := specializedArith[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no specialized arithmetic for %v", ErrUnsupported, id)
+	}
+	prime, err := FromTypeE(p)
+	if err != nil {
+		return nil, err
+	}
+	f := &fpCT{big: prime, id: id, mulFn: a.mul, mont: a.mont}
+	if err := f.precmp(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fpCT) precmp() (err error) {
+	n := (f.big.BitLen() + 63) / 64
+	f.p = f.fromBig(f.big, n)
+
+	// pp = -1/p mod 2^64
+	w := new(big.Int).Lsh(big.NewInt(1), 64)
+	pp := new(big.Int).ModInverse(new(big.Int).Mod(f.big, w), w)
+	f.pp = -pp.Uint64()
+
+	R := big.NewInt(1)
+	if f.mont {
+		R.Lsh(R, uint(64*n))
+	}
+	f.one = f.fromBig(new(big.Int).Mod(R, f.big), n)
+	f.r2 = f.fromBig(new(big.Int).Exp(R, big.NewInt(2), f.big), n)
+
+	pMinus1div2 := new(big.Int).Sub(f.big, big.NewInt(1))
+	pMinus1div2.Rsh(pMinus1div2, 1)
+	f.half = f.fromBig(pMinus1div2, n)
+	f.cte.pMinus1div2 = pMinus1div2
+	f.cte.pMinus2 = new(big.Int).Sub(f.big, big.NewInt(2))
+	f.hasSqrt, err = generateSqrt(f)
+	return err
+}
+
+// fromBig returns the n limbs of x, which must be non-negative.
+func (f *fpCT) fromBig(x *big.Int, n int) []uint64 {
+	z := make([]uint64, n)
+	b := x.FillBytes(make([]byte, 8*n))
+	for i := range z {
+		for j := 0; j < 8; j++ {
+			z[i] |= uint64(b[8*n-1-8*i-j]) << (8 * uint(j))
+		}
+	}
+	return z
+}
+
+// toBig returns the integer represented by x outside the Montgomery domain.
+func (f *fpCT) toBig(x *fpCTElt) *big.Int {
+	z := f.fromMont(x)
+	b := make([]byte, 8*len(z))
+	for i := range z {
+		for j := 0; j < 8; j++ {
+			b[len(b)-1-8*i-j] = byte(z[i] >> (8 * uint(j)))
+		}
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func (f *fpCT) elt(v []uint64) *fpCTElt { return &fpCTElt{f, v} }
+func (f *fpCT) new() *fpCTElt           { return f.elt(make([]uint64, len(f.p))) }
+
+func (f *fpCT) String() string {
+	if f.id.String() == "" {
+		return fmt.Sprintf("GF(%v)", f.big)
+	}
+	return fmt.Sprintf("GF(%v)", f.id)
+}
+func (f *fpCT) Zero() Elt               { return f.new() }
+func (f *fpCT) One() Elt                { return f.elt(append([]uint64(nil), f.one...)) }
+func (f *fpCT) Rand(r io.Reader) Elt    { e, _ := rand.Int(r, f.big); return f.Elt(e) }
+func (f *fpCT) Coeffs(x Elt) []*big.Int { return []*big.Int{f.toBig(x.(*fpCTElt))} }
+func (f *fpCT) P() *big.Int             { return new(big.Int).Set(f.big) }
+func (f *fpCT) Order() *big.Int         { return new(big.Int).Set(f.big) }
+func (f *fpCT) Ext() uint               { return uint(1) }
+func (f *fpCT) BitLen() int             { return f.big.BitLen() }
+func (f *fpCT) Elt(in interface{}) Elt {
+	var n *big.Int
+	if v, ok := in.([]interface{}); ok && len(v) == 1 {
+		n = FromType(v[0])
+	} else {
+		n = FromType(in)
+	}
+	z := f.new()
+	f.mul(z.v, f.fromBig(n.Mod(n, f.big), len(f.p)), f.r2)
+	return z
+}
+
+// Implementing hasPredicates
+
+func (f *fpCT) IsZero(x Elt) bool { return isZeroCT(x.(*fpCTElt).v) == 1 }
+func (f *fpCT) AreEqual(x, y Elt) bool {
+	var acc uint64
+	xv, yv := x.(*fpCTElt).v, y.(*fpCTElt).v
+	for i := range xv {
+		acc |= xv[i] ^ yv[i]
+	}
+	return isZeroCT([]uint64{acc}) == 1
+}
+func (f *fpCT) IsSquare(x Elt) bool {
+	// x is a square if x^((p-1)/2) is either 0 or 1.
+	t := f.Exp(x, f.cte.pMinus1div2).(*fpCTElt)
+	return isZeroCT(t.v)|isZeroCT(f.Sub(t, f.One()).(*fpCTElt).v) == 1
+}
+func (f *fpCT) IsEqual(ff Field) bool {
+	g, ok := ff.(*fpCT)
+	return ok && f.big.Cmp(g.big) == 0
+}
+
+// isZeroCT returns 1 if all limbs of x are zero, otherwise returns 0.
+func isZeroCT(x []uint64) uint64 {
+	var acc uint64
+	for i := range x {
+		acc |= x[i]
+	}
+	return 1 ^ ((acc | -acc) >> 63)
+}
+
+// Implementing hasArith
+
+func (f *fpCT) Neg(x Elt) Elt { return f.Sub(f.Zero(), x) }
+func (f *fpCT) Add(x, y Elt) Elt {
+	z := f.new()
+	xv, yv := x.(*fpCTElt).v, y.(*fpCTElt).v
+	var carry uint64
+	for i := range z.v {
+		z.v[i], carry = bits.Add64(xv[i], yv[i], carry)
+	}
+	condSub(z.v, carry, f.p)
+	return z
+}
+func (f *fpCT) Sub(x, y Elt) Elt {
+	z := f.new()
+	xv, yv := x.(*fpCTElt).v, y.(*fpCTElt).v
+	var borrow uint64
+	for i := range z.v {
+		z.v[i], borrow = bits.Sub64(xv[i], yv[i], borrow)
+	}
+	// Adds p if there was a borrow.
+	mask := -borrow
+	var carry uint64
+	for i := range z.v {
+		z.v[i], carry = bits.Add64(z.v[i], f.p[i]&mask, carry)
+	}
+	return z
+}
+func (f *fpCT) Mul(x, y Elt) Elt {
+	z := f.new()
+	f.mul(z.v, x.(*fpCTElt).v, y.(*fpCTElt).v)
+	return z
+}
+func (f *fpCT) Sqr(x Elt) Elt { return f.Mul(x, x) }
+func (f *fpCT) Inv(x Elt) Elt { return f.Exp(x, f.cte.pMinus2) }
+
+// Exp returns x^e using a square-and-multiply-always method, so the running
+// time depends on the bit length of e but not on x.
+func (f *fpCT) Exp(x Elt, e *big.Int) Elt {
+	xv := x.(*fpCTElt).v
+	z := f.One().(*fpCTElt)
+	t := make([]uint64, len(f.p))
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		f.mul(z.v, z.v, z.v)
+		f.mul(t, z.v, xv)
+		mask := -uint64(e.Bit(i))
+		for j := range t {
+			z.v[j] ^= mask & (z.v[j] ^ t[j])
+		}
+	}
+	return z
+}
+
+// condSub subtracts p from the number (hi,z) if it is larger than or equal to
+// p, assuming that (hi,z) < 2p.
+func condSub(z []uint64, hi uint64, p []uint64) {
+	var buf [9]uint64 // Enough for the well-known primes.
+	t := buf[:]
+	if len(z) > len(buf) {
+		t = make([]uint64, len(z))
+	}
+	t = t[:len(z)]
+	var borrow uint64
+	for i := range z {
+		t[i], borrow = bits.Sub64(z[i], p[i], borrow)
+	}
+	_, borrow = bits.Sub64(hi, 0, borrow)
+	// Keeps z if there was a borrow, otherwise takes t.
+	mask := -borrow
+	for i := range z {
+		z[i] = (z[i] & mask) | (t[i] &^ mask)
+	}
+}
+
+// mul sets z = x*y/R mod p using mulFn if it is set, otherwise it uses the
+// Coarsely Integrated Operand Scanning method of Montgomery multiplication
+// (https://doi.org/10.1109/40.502403).
+func (f *fpCT) mul(z, x, y []uint64) {
+	if f.mulFn != nil {
+		f.mulFn(z, x, y)
+		return
+	}
+	n := len(f.p)
+	var buf [11]uint64 // Enough for the well-known primes.
+	t := buf[:]
+	if n+2 > len(buf) {
+		t = make([]uint64, n+2)
+	}
+	t = t[:n+2]
+	for i := 0; i < n; i++ {
+		var c, hi, lo uint64
+		for j := 0; j < n; j++ {
+			hi, lo = mulAdd(x[j], y[i], t[j], c)
+			t[j], c = lo, hi
+		}
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] = c
+
+		m := t[0] * f.pp
+		hi, _ = mulAdd(m, f.p[0], t[0], 0)
+		c = hi
+		for j := 1; j < n; j++ {
+			hi, lo = mulAdd(m, f.p[j], t[j], c)
+			t[j-1], c = lo, hi
+		}
+		t[n-1], c = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + c
+	}
+	copy(z, t[:n])
+	condSub(z, t[n], f.p)
+}
+
+// mulAdd returns (hi,lo) = a*b+c+d.
+func mulAdd(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return
+}
+
+// Implementing extended operations
+
+func (f *fpCT) Generator() Elt { return f.One() }
+func (f *fpCT) Inv0(x Elt) Elt { return f.Inv(x) }
+func (f *fpCT) GetSgn0(id Sgn0ID) func(Elt) int {
+	if id == SignBE {
+		return f.Sgn0BE
+	}
+	if id == SignLE {
+		return f.Sgn0LE
+	}
+	panic("Wrong signID")
+}
+
+// Sgn0BE returns -1 if x > (p-1)/2, otherwise returns 1.
+func (f *fpCT) Sgn0BE(x Elt) int {
+	v := f.fromMont(x.(*fpCTElt))
+	var borrow uint64
+	for i := range v {
+		_, borrow = bits.Sub64(f.half[i], v[i], borrow)
+	}
+	return 1 - 2*int(borrow)
+}
+
+// Sgn0LE returns -1 if x is odd, otherwise returns 1.
+func (f *fpCT) Sgn0LE(x Elt) int { return 1 - 2*int(f.fromMont(x.(*fpCTElt))[0]&1) }
+
+func (f *fpCT) CMov(x, y Elt, b bool) Elt {
+	mask := -boolToUint64(b)
+	z := f.new()
+	xv, yv := x.(*fpCTElt).v, y.(*fpCTElt).v
+	for i := range z.v {
+		z.v[i] = xv[i] ^ (mask & (xv[i] ^ yv[i]))
+	}
+	return z
+}
+
+// fromMont returns the limbs of x outside the Montgomery domain, if x is not in
+// the Montgomery domain it returns a copy of x.
+func (f *fpCT) fromMont(x *fpCTElt) []uint64 {
+	z := make([]uint64, len(f.p))
+	one := make([]uint64, len(f.p))
+	one[0] = 1
+	f.mul(z, x.v, one)
+	return z
+}
+
+// boolToUint64 returns 1 if b is true, otherwise returns 0. The compiler emits
+// a conditional set instruction instead of a branch.
+func boolToUint64(b bool) uint64 {
+	var v uint64
+	if b {
+		v = 1
+	}
+	return v
+}
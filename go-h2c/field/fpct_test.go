@@ -0,0 +1,96 @@
+package field_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+func TestFpCT(t *testing.T) {
+	ids := []GF.ID{GF.P25519, GF.P256, GF.P256K1, GF.P384, GF.P448, GF.P521, GF.BLS12381}
+	for _, id := range ids {
+		F := id.Get()
+		t.Run(id.String(), func(t *testing.T) { testFpCT(t, F, GF.NewFpCT(id, F.P())) })
+	}
+	for _, id := range ids {
+		F0 := id.Get()
+		F1 := id.GetBackend(GF.Specialized)
+		t.Run("Specialized/"+id.String(), func(t *testing.T) { testFpCT(t, F0, F1) })
+	}
+	for _, p := range []int{53, 607, 613} {
+		t.Run(fmt.Sprint(p), func(t *testing.T) { testFpCT(t, GF.NewFp(0, p), GF.NewFpCT(0, p)) })
+	}
+}
+
+// testFpCT compares the results of the constant-time field F1 against F0.
+func testFpCT(t *testing.T, F0, F1 GF.Field) {
+	p := F0.P()
+	check := func(op string, got, want interface{}) {
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("%v\ngot:  %v\nwant: %v", op, got, want)
+		}
+	}
+	pMinus1 := new(big.Int).Sub(p, big.NewInt(1))
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), pMinus1}
+	for i := 0; i < 32; i++ {
+		v, _ := rand.Int(rand.Reader, p)
+		values = append(values, v)
+	}
+	for _, a := range values {
+		x0, x1 := F0.Elt(a), F1.Elt(a)
+		check("Elt", x1, x0)
+		check("Neg", F1.Neg(x1), F0.Neg(x0))
+		check("Sqr", F1.Sqr(x1), F0.Sqr(x0))
+		check("IsZero", F1.IsZero(x1), F0.IsZero(x0))
+		check("IsSquare", F1.IsSquare(x1), F0.IsSquare(x0))
+		check("Sgn0LE", F1.GetSgn0(GF.SignLE)(x1), F0.GetSgn0(GF.SignLE)(x0))
+		check("Sgn0BE", F1.GetSgn0(GF.SignBE)(x1), F0.GetSgn0(GF.SignBE)(x0))
+		if !F0.IsZero(x0) {
+			check("Inv", F1.Inv(x1), F0.Inv(x0))
+		}
+		if F0.IsSquare(x0) {
+			got := F1.Sqrt(x1)
+			check("Sqrt", F1.Sqr(got), x0)
+		}
+		for _, b := range values[:8] {
+			y0, y1 := F0.Elt(b), F1.Elt(b)
+			check("Add", F1.Add(x1, y1), F0.Add(x0, y0))
+			check("Sub", F1.Sub(x1, y1), F0.Sub(x0, y0))
+			check("Mul", F1.Mul(x1, y1), F0.Mul(x0, y0))
+			check("Exp", F1.Exp(x1, b), F0.Exp(x0, b))
+			check("AreEqual", F1.AreEqual(x1, y1), F0.AreEqual(x0, y0))
+			check("CMov", F1.CMov(x1, y1, false), x0)
+			check("CMov", F1.CMov(x1, y1, true), y0)
+		}
+	}
+}
+
+func BenchmarkFpCT(b *testing.B) {
+	F0 := GF.P256.Get()
+	F2 := GF.P256.GetBackend(GF.Specialized)
+	for _, v := range []struct {
+		name string
+		F    GF.Field
+	}{
+		{"BigInt", F0},
+		{"ConstantTime", GF.NewFpCT(GF.P256, F0.P())},
+		{"Specialized", F2},
+	} {
+		F := v.F
+		x := F.Rand(rand.Reader)
+		y := F.Rand(rand.Reader)
+		b.Run(v.name+"/Mul", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				F.Mul(x, y)
+			}
+		})
+		b.Run(v.name+"/Inv", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				F.Inv(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package field
+
+//go:generate go run ./internal/gen -o wkarith.go
+
+// limbArith is a multiplication generated for a well-known prime, which
+// operates on elements either in the Montgomery domain or not.
+type limbArith struct {
+	mul  func(z, x, y []uint64)
+	mont bool
+}
+
+// solinasNormalize reduces the number given by the 32-bit signed columns s
+// modulo p=2^(32*len(s))-c, where c is given as 32-bit signed words and c is
+// smaller than 2^(32*(len(s)-1)). It stores the result in z.
+func solinasNormalize(z []uint64, s, c []int64, p []uint64) {
+	n := len(s)
+	var top int64
+	// The first pass leaves a small carry out, then the second pass leaves
+	// a carry out of at most one, so the third pass leaves no carry out.
+	for pass := 0; pass < 3; pass++ {
+		for i := range s {
+			s[i] += top * c[i]
+		}
+		top = 0
+		for i := range s {
+			s[i] += top
+			top = s[i] >> 32
+			s[i] &= 0xffffffff
+		}
+	}
+	for i := range z {
+		z[i] = uint64(s[2*i])
+		if 2*i+1 < n {
+			z[i] |= uint64(s[2*i+1]) << 32
+		}
+	}
+	condSub(z, 0, p)
+}
@@ -5,8 +5,19 @@ import (
 	"math/big"
 )
 
-// FromType converts an int, uint or string to a big.Int.
+// FromType converts an int, uint or string to a big.Int. It panics if the
+// conversion fails, see FromTypeE.
 func FromType(in interface{}) *big.Int {
+	n, err := FromTypeE(in)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// FromTypeE converts an int, uint or string to a big.Int, or returns
+// ErrInvalidType if the conversion fails.
+func FromTypeE(in interface{}) (*big.Int, error) {
 	n := new(big.Int)
 	switch s := in.(type) {
 	case *big.Int:
@@ -15,7 +26,7 @@ func FromType(in interface{}) *big.Int {
 		n.Set(&s)
 	case string:
 		if _, ok := n.SetString(s, 0); !ok {
-			panic("error setting the number")
+			return nil, fmt.Errorf("%w: %q", ErrInvalidType, s)
 		}
 	case uint:
 		n.SetUint64(uint64(s))
@@ -38,7 +49,7 @@ func FromType(in interface{}) *big.Int {
 	case int64:
 		n.SetInt64(int64(s))
 	default:
-		panic(fmt.Errorf("type %T not supported", in))
+		return nil, fmt.Errorf("%w: type %T not supported", ErrInvalidType, in)
 	}
-	return n
+	return n, nil
 }
@@ -0,0 +1,342 @@
+// The gen command generates the specialized arithmetic of the well-known prime
+// fields, it is invoked by go generate from the field package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"strings"
+)
+
+// Kinds of reduction.
+const (
+	pseudoMersenne = iota // p = 2^k-c, c < 2^64.
+	solinas               // p = 2^k-c, c is a sum of few powers of 2^32.
+	montgomery            // p has no special form.
+)
+
+type prime struct {
+	ID   string // Identifier of the field.ID constant.
+	P    string // The prime modulus.
+	Kind int
+	K    uint // Such that p=2^k-c for pseudoMersenne and solinas.
+}
+
+var primes = []prime{
+	{"P25519", "2^255-19", pseudoMersenne, 255},
+	{"P256", "2^256-2^224+2^192+2^96-1", solinas, 256},
+	{"P256K1", "2^256-2^32-977", pseudoMersenne, 256},
+	{"P384", "2^384-2^128-2^96+2^32-1", solinas, 384},
+	{"P448", "2^448-2^224-1", solinas, 448},
+	{"P521", "2^521-1", pseudoMersenne, 521},
+	{"BLS12381", "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", montgomery, 0},
+}
+
+func main() {
+	out := flag.String("o", "wkarith.go", "output file")
+	flag.Parse()
+
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by internal/gen; DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "package field")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "import \"math/bits\"")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "var specializedArith = map[ID]limbArith{")
+	for _, p := range primes {
+		fmt.Fprintf(&b, "%v: {mul: mul%v, mont: %v},\n", p.ID, p.ID, p.Kind == montgomery)
+	}
+	fmt.Fprintln(&b, "}")
+	for _, p := range primes {
+		g := newGen(&b, p)
+		switch p.Kind {
+		case pseudoMersenne:
+			g.pseudoMersenne()
+		case solinas:
+			g.solinas()
+		case montgomery:
+			g.montgomery()
+		}
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type gen struct {
+	*bytes.Buffer
+	prime
+	p *big.Int
+	c *big.Int // c = 2^k-p.
+	n int      // Number of 64-bit limbs.
+}
+
+func newGen(b *bytes.Buffer, p prime) *gen {
+	g := &gen{Buffer: b, prime: p, p: parse(p.P)}
+	g.n = (g.p.BitLen() + 63) / 64
+	if p.Kind != montgomery {
+		g.c = new(big.Int).Lsh(big.NewInt(1), p.K)
+		g.c.Sub(g.c, g.p)
+	}
+	return g
+}
+
+// parse reads a number given as a sum of signed powers of two, or as an
+// integer literal.
+func parse(s string) *big.Int {
+	if !strings.Contains(s, "^") {
+		n, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			log.Fatalf("invalid number %v", s)
+		}
+		return n
+	}
+	n := new(big.Int)
+	s = strings.Replace(s, "-", "+-", -1)
+	for _, term := range strings.Split(s, "+") {
+		t := new(big.Int)
+		neg := strings.HasPrefix(term, "-")
+		term = strings.TrimPrefix(term, "-")
+		if i := strings.Index(term, "^"); i >= 0 {
+			var e uint
+			fmt.Sscan(term[i+1:], &e)
+			t.Lsh(big.NewInt(1), e)
+		} else {
+			t.SetString(term, 10)
+		}
+		if neg {
+			t.Neg(t)
+		}
+		n.Add(n, t)
+	}
+	return n
+}
+
+// limbs returns the 64-bit limbs of x in little-endian order.
+func limbs(x *big.Int, n int) []uint64 {
+	z := make([]uint64, n)
+	t := new(big.Int).Set(x)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := range z {
+		z[i] = new(big.Int).And(t, mask).Uint64()
+		t.Rsh(t, 64)
+	}
+	return z
+}
+
+func (g *gen) header(method string) {
+	fmt.Fprintf(g, "\n// mul%v sets z = x*y mod %v using %v.\n", g.ID, g.P, method)
+	fmt.Fprintf(g, "func mul%v(z, x, y []uint64) {\n", g.ID)
+}
+
+func (g *gen) modulus() {
+	fmt.Fprintf(g, "p := [%v]uint64{", g.n)
+	for _, l := range limbs(g.p, g.n) {
+		fmt.Fprintf(g, "%#x,", l)
+	}
+	fmt.Fprintln(g, "}")
+}
+
+// product emits the schoolbook multiplication t = x*y.
+func (g *gen) product() {
+	n := g.n
+	fmt.Fprintf(g, "var t [%v]uint64\n", 2*n)
+	fmt.Fprintln(g, "var c, hi, lo uint64")
+	for i := 0; i < n; i++ {
+		fmt.Fprintln(g, "c = 0")
+		for j := 0; j < n; j++ {
+			fmt.Fprintf(g, "hi, lo = mulAdd(x[%v], y[%v], t[%v], c)\n", j, i, i+j)
+			fmt.Fprintf(g, "t[%v], c = lo, hi\n", i+j)
+		}
+		fmt.Fprintf(g, "t[%v] = c\n", i+n)
+	}
+}
+
+// pseudoMersenne emits a reduction that folds twice the bits above 2^k using
+// 2^k = c mod p.
+func (g *gen) pseudoMersenne() {
+	if g.c.BitLen() > 64 {
+		log.Fatalf("c is too large for %v", g.ID)
+	}
+	g.header("pseudo-Mersenne reduction")
+	g.modulus()
+	g.product()
+	c := g.c.Uint64()
+	in := "t"
+	m := 2 * g.n
+	for fold := 0; fold < 2; fold++ {
+		out := fmt.Sprintf("u%v", fold)
+		m = g.fold(in, out, m, c)
+		in = out
+	}
+	fmt.Fprintf(g, "copy(z, %v[:%v])\n", in, g.n)
+	fmt.Fprintf(g, "condSub(z, %v[%v], p[:])\n", in, g.n)
+	fmt.Fprintln(g, "}")
+}
+
+// fold emits out = (in mod 2^k) + (in >> k)*c, where in has m limbs, and
+// returns the number of limbs of out.
+func (g *gen) fold(in, out string, m int, c uint64) int {
+	q, r := int(g.K/64), g.K%64
+	hn := m - q // Number of limbs of in >> k.
+	ln := g.n   // Number of limbs of in mod 2^k.
+	on := hn
+	if ln > on {
+		on = ln
+	}
+	on++
+	fmt.Fprintf(g, "var %v [%v]uint64\n", out, on)
+	fmt.Fprintln(g, "c = 0")
+	for i := 0; i < on-1; i++ {
+		h := "0"
+		if i < hn {
+			if r == 0 {
+				h = fmt.Sprintf("%v[%v]", in, q+i)
+			} else if q+i+1 < m {
+				h = fmt.Sprintf("%v[%v]>>%v | %v[%v]<<%v", in, q+i, r, in, q+i+1, 64-r)
+			} else {
+				h = fmt.Sprintf("%v[%v]>>%v", in, q+i, r)
+			}
+		}
+		l := "0"
+		if i < ln {
+			l = fmt.Sprintf("%v[%v]", in, i)
+			if i == q && r != 0 {
+				l = fmt.Sprintf("%v[%v]&%#x", in, i, uint64(1)<<r-1)
+			} else if i >= q && r == 0 {
+				l = "0"
+			}
+		}
+		fmt.Fprintf(g, "hi, lo = mulAdd(%v, %#x, %v, c)\n", h, c, l)
+		fmt.Fprintf(g, "%v[%v], c = lo, hi\n", out, i)
+	}
+	fmt.Fprintf(g, "%v[%v] = c\n", out, on-1)
+	return on
+}
+
+// solinas emits a reduction that adds the 32-bit words above 2^k multiplied
+// by small signed coefficients, as in the NIST reduction algorithms.
+func (g *gen) solinas() {
+	if g.K%32 != 0 || 32*(g.n*2) < int(g.K) {
+		log.Fatalf("k must be a multiple of 32 for %v", g.ID)
+	}
+	w := int(g.K / 32)
+	c := signedWords(g.c, w)
+	if c[w-1] != 0 && c[w-1] != 1 && c[w-1] != -1 {
+		log.Fatalf("c is too large for %v", g.ID)
+	}
+	// M[j] holds 2^(32j) mod p for w <= j < 2w as signed 32-bit words.
+	M := make([][]int64, 2*w)
+	for j := w; j < 2*w; j++ {
+		v := make([]int64, 2*w)
+		v[j] = 1
+		for e := 2*w - 1; e >= w; e-- {
+			a := v[e]
+			v[e] = 0
+			for i := range c {
+				v[e-w+i] += a * c[i]
+			}
+		}
+		M[j] = v[:w]
+	}
+
+	g.header("Solinas reduction")
+	g.modulus()
+	g.product()
+	fmt.Fprintf(g, "var s [%v]int64\n", w)
+	for i := 0; i < w; i++ {
+		fmt.Fprintf(g, "s[%v] = %v", i, word(i))
+		for j := w; j < 2*w; j++ {
+			switch a := M[j][i]; {
+			case a == 1:
+				fmt.Fprintf(g, " + %v", word(j))
+			case a == -1:
+				fmt.Fprintf(g, " - %v", word(j))
+			case a > 0:
+				fmt.Fprintf(g, " + %v*%v", a, word(j))
+			case a < 0:
+				fmt.Fprintf(g, " - %v*%v", -a, word(j))
+			}
+		}
+		fmt.Fprintln(g)
+	}
+	fmt.Fprintf(g, "solinasNormalize(z, s[:], []int64{")
+	for i := range c {
+		fmt.Fprintf(g, "%v,", c[i])
+	}
+	fmt.Fprintln(g, "}, p[:])")
+	fmt.Fprintln(g, "}")
+}
+
+// word returns the expression of the j-th 32-bit word of t.
+func word(j int) string {
+	if j%2 == 0 {
+		return fmt.Sprintf("int64(t[%v]&0xffffffff)", j/2)
+	}
+	return fmt.Sprintf("int64(t[%v]>>32)", j/2)
+}
+
+// signedWords returns x as w signed 32-bit words in the range [-2^31,2^31).
+func signedWords(x *big.Int, w int) []int64 {
+	z := make([]int64, w)
+	t := new(big.Int).Set(x)
+	mask := big.NewInt(0xffffffff)
+	var carry int64
+	for i := range z {
+		d := new(big.Int).And(t, mask).Int64() + carry
+		t.Rsh(t, 32)
+		carry = 0
+		if d >= 1<<31 {
+			d -= 1 << 32
+			carry = 1
+		}
+		z[i] = d
+	}
+	if carry != 0 || t.Sign() != 0 {
+		log.Fatalf("number does not fit in %v words", w)
+	}
+	return z
+}
+
+// montgomery emits an unrolled Montgomery multiplication (CIOS method).
+func (g *gen) montgomery() {
+	n := g.n
+	w := new(big.Int).Lsh(big.NewInt(1), 64)
+	pp := new(big.Int).ModInverse(new(big.Int).Mod(g.p, w), w)
+	pp.Sub(w, pp)
+	pl := limbs(g.p, n)
+
+	g.header("Montgomery multiplication")
+	g.modulus()
+	fmt.Fprintf(g, "var t [%v]uint64\n", n+2)
+	fmt.Fprintln(g, "var c, hi, lo, m uint64")
+	for i := 0; i < n; i++ {
+		fmt.Fprintln(g, "c = 0")
+		for j := 0; j < n; j++ {
+			fmt.Fprintf(g, "hi, lo = mulAdd(x[%v], y[%v], t[%v], c)\n", j, i, j)
+			fmt.Fprintf(g, "t[%v], c = lo, hi\n", j)
+		}
+		fmt.Fprintf(g, "t[%v], t[%v] = bits.Add64(t[%v], c, 0)\n", n, n+1, n)
+		fmt.Fprintf(g, "m = t[0] * %#x\n", pp.Uint64())
+		fmt.Fprintf(g, "c, _ = mulAdd(m, %#x, t[0], 0)\n", pl[0])
+		for j := 1; j < n; j++ {
+			fmt.Fprintf(g, "hi, lo = mulAdd(m, %#x, t[%v], c)\n", pl[j], j)
+			fmt.Fprintf(g, "t[%v], c = lo, hi\n", j-1)
+		}
+		fmt.Fprintf(g, "t[%v], c = bits.Add64(t[%v], c, 0)\n", n-1, n)
+		fmt.Fprintf(g, "t[%v] = t[%v] + c\n", n, n+1)
+	}
+	fmt.Fprintf(g, "copy(z, t[:%v])\n", n)
+	fmt.Fprintf(g, "condSub(z, t[%v], p[:])\n", n)
+	fmt.Fprintln(g, "}")
+}
@@ -0,0 +1,125 @@
+package field
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SqrtRatio is the sqrt_ratio routine for a fixed non-square Z as specified
+// in RFC 9380 (Appendix F.2.1).
+type SqrtRatio interface {
+	// SqrtRatio returns (true, sqrt(u/v)) if u/v is a square, otherwise
+	// returns (false, sqrt(Z*u/v)). It requires v != 0.
+	SqrtRatio(u, v Elt) (bool, Elt)
+}
+
+// NewSqrtRatio returns the sqrt_ratio routine of the field f for the
+// non-square Z. It panics if Z is a square, see NewSqrtRatioE.
+func NewSqrtRatio(f Field, Z Elt) SqrtRatio {
+	s, err := NewSqrtRatioE(f, Z)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewSqrtRatioE is like NewSqrtRatio but it returns ErrSquare if Z is a
+// square.
+func NewSqrtRatioE(f Field, Z Elt) (SqrtRatio, error) {
+	if f.IsSquare(Z) {
+		return nil, fmt.Errorf("%w: Z: %v", ErrSquare, Z)
+	}
+	four := big.NewInt(4)
+	if new(big.Int).Mod(f.Order(), four).Int64() == 3 {
+		return generateSqrtRatio3mod4(f, Z), nil
+	}
+	return generateSqrtRatioGeneric(f, Z), nil
+}
+
+type sqrtRatioGeneric struct {
+	Field
+	c1         int
+	c3, c4, c5 *big.Int
+	c6, c7     Elt
+}
+
+func generateSqrtRatioGeneric(f Field, Z Elt) SqrtRatio {
+	// c1, the largest integer such that 2^c1 divides q - 1.
+	// c2 = (q - 1) / (2^c1)
+	// c3 = (c2 - 1) / 2
+	// c4 = 2^c1 - 1
+	// c5 = 2^(c1 - 1)
+	// c6 = Z^c2
+	// c7 = Z^((c2 + 1) / 2)
+	qMinus1 := new(big.Int).Sub(f.Order(), big.NewInt(1))
+	c1 := int(qMinus1.TrailingZeroBits())
+	c2 := new(big.Int).Rsh(qMinus1, uint(c1))
+	c3 := new(big.Int).Rsh(c2, 1)
+	c4 := new(big.Int).Lsh(big.NewInt(1), uint(c1))
+	c4.Sub(c4, big.NewInt(1))
+	c5 := new(big.Int).Lsh(big.NewInt(1), uint(c1-1))
+	c6 := f.Exp(Z, c2)
+	c7 := f.Exp(Z, new(big.Int).Rsh(new(big.Int).Add(c2, big.NewInt(1)), 1))
+	return sqrtRatioGeneric{Field: f, c1: c1, c3: c3, c4: c4, c5: c5, c6: c6, c7: c7}
+}
+
+func (s sqrtRatioGeneric) SqrtRatio(u, v Elt) (bool, Elt) {
+	tv1 := s.c6                      // 1. tv1 = c6
+	tv2 := s.Exp(v, s.c4)            // 2. tv2 = v^c4
+	tv3 := s.Sqr(tv2)                // 3. tv3 = tv2^2
+	tv3 = s.Mul(tv3, v)              // 4. tv3 = tv3 * v
+	tv5 := s.Mul(u, tv3)             // 5. tv5 = u * tv3
+	tv5 = s.Exp(tv5, s.c3)           // 6. tv5 = tv5^c3
+	tv5 = s.Mul(tv5, tv2)            // 7. tv5 = tv5 * tv2
+	tv2 = s.Mul(tv5, v)              // 8. tv2 = tv5 * v
+	tv3 = s.Mul(tv5, u)              // 9. tv3 = tv5 * u
+	tv4 := s.Mul(tv3, tv2)           // 10. tv4 = tv3 * tv2
+	tv5 = s.Exp(tv4, s.c5)           // 11. tv5 = tv4^c5
+	isQR := s.AreEqual(tv5, s.One()) // 12. isQR = tv5 == 1
+	tv2 = s.Mul(tv3, s.c7)           // 13. tv2 = tv3 * c7
+	tv5 = s.Mul(tv4, tv1)            // 14. tv5 = tv4 * tv1
+	tv3 = s.CMov(tv2, tv3, isQR)     // 15. tv3 = CMOV(tv2, tv3, isQR)
+	tv4 = s.CMov(tv5, tv4, isQR)     // 16. tv4 = CMOV(tv5, tv4, isQR)
+	for i := s.c1; i >= 2; i-- {     // 17. for i in (c1, c1 - 1, ..., 2):
+		tv5 = tv4 // 18-20. tv5 = tv4^(2^(i-2))
+		for j := 0; j < i-2; j++ {
+			tv5 = s.Sqr(tv5)
+		}
+		e1 := s.AreEqual(tv5, s.One()) // 21. e1 = tv5 == 1
+		tv2 = s.Mul(tv3, tv1)          // 22. tv2 = tv3 * tv1
+		tv1 = s.Sqr(tv1)               // 23. tv1 = tv1 * tv1
+		tv5 = s.Mul(tv4, tv1)          // 24. tv5 = tv4 * tv1
+		tv3 = s.CMov(tv2, tv3, e1)     // 25. tv3 = CMOV(tv2, tv3, e1)
+		tv4 = s.CMov(tv5, tv4, e1)     // 26. tv4 = CMOV(tv5, tv4, e1)
+	}
+	// Zero is a square, as in the 3 mod 4 case.
+	return isQR || s.IsZero(u), tv3 // 27. return (isQR, tv3)
+}
+
+type sqrtRatio3mod4 struct {
+	Field
+	c1 *big.Int
+	c2 Elt
+}
+
+func generateSqrtRatio3mod4(f Field, Z Elt) SqrtRatio {
+	// c1 = (q - 3) / 4
+	// c2 = sqrt(-Z)
+	c1 := new(big.Int).Sub(f.Order(), big.NewInt(3))
+	c1.Rsh(c1, 2)
+	c2 := f.Sqrt(f.Neg(Z))
+	return sqrtRatio3mod4{Field: f, c1: c1, c2: c2}
+}
+
+func (s sqrtRatio3mod4) SqrtRatio(u, v Elt) (bool, Elt) {
+	tv1 := s.Sqr(v)                   // 1. tv1 = v^2
+	tv2 := s.Mul(u, v)                // 2. tv2 = u * v
+	tv1 = s.Mul(tv1, tv2)             // 3. tv1 = tv1 * tv2
+	y1 := s.Exp(tv1, s.c1)            // 4. y1 = tv1^c1
+	y1 = s.Mul(y1, tv2)               // 5. y1 = y1 * tv2
+	y2 := s.Mul(y1, s.c2)             // 6. y2 = y1 * c2
+	tv3 := s.Sqr(y1)                  // 7. tv3 = y1^2
+	tv3 = s.Mul(tv3, v)               // 8. tv3 = tv3 * v
+	isQR := s.AreEqual(tv3, u)        // 9. isQR = tv3 == u
+	return isQR, s.CMov(y2, y1, isQR) // 10-11. y = CMOV(y2, y1, isQR)
+}
@@ -0,0 +1,866 @@
+// Code generated by internal/gen; DO NOT EDIT.
+
+package field
+
+import "math/bits"
+
+var specializedArith = map[ID]limbArith{
+	P25519:   {mul: mulP25519, mont: false},
+	P256:     {mul: mulP256, mont: false},
+	P256K1:   {mul: mulP256K1, mont: false},
+	P384:     {mul: mulP384, mont: false},
+	P448:     {mul: mulP448, mont: false},
+	P521:     {mul: mulP521, mont: false},
+	BLS12381: {mul: mulBLS12381, mont: true},
+}
+
+// mulP25519 sets z = x*y mod 2^255-19 using pseudo-Mersenne reduction.
+func mulP25519(z, x, y []uint64) {
+	p := [4]uint64{0xffffffffffffffed, 0xffffffffffffffff, 0xffffffffffffffff, 0x7fffffffffffffff}
+	var t [8]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	t[4] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	t[5] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	t[6] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	t[7] = c
+	var u0 [6]uint64
+	c = 0
+	hi, lo = mulAdd(t[3]>>63|t[4]<<1, 0x13, t[0], c)
+	u0[0], c = lo, hi
+	hi, lo = mulAdd(t[4]>>63|t[5]<<1, 0x13, t[1], c)
+	u0[1], c = lo, hi
+	hi, lo = mulAdd(t[5]>>63|t[6]<<1, 0x13, t[2], c)
+	u0[2], c = lo, hi
+	hi, lo = mulAdd(t[6]>>63|t[7]<<1, 0x13, t[3]&0x7fffffffffffffff, c)
+	u0[3], c = lo, hi
+	hi, lo = mulAdd(t[7]>>63, 0x13, 0, c)
+	u0[4], c = lo, hi
+	u0[5] = c
+	var u1 [5]uint64
+	c = 0
+	hi, lo = mulAdd(u0[3]>>63|u0[4]<<1, 0x13, u0[0], c)
+	u1[0], c = lo, hi
+	hi, lo = mulAdd(u0[4]>>63|u0[5]<<1, 0x13, u0[1], c)
+	u1[1], c = lo, hi
+	hi, lo = mulAdd(u0[5]>>63, 0x13, u0[2], c)
+	u1[2], c = lo, hi
+	hi, lo = mulAdd(0, 0x13, u0[3]&0x7fffffffffffffff, c)
+	u1[3], c = lo, hi
+	u1[4] = c
+	copy(z, u1[:4])
+	condSub(z, u1[4], p[:])
+}
+
+// mulP256 sets z = x*y mod 2^256-2^224+2^192+2^96-1 using Solinas reduction.
+func mulP256(z, x, y []uint64) {
+	p := [4]uint64{0xffffffffffffffff, 0xffffffff, 0x0, 0xffffffff00000001}
+	var t [8]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	t[4] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	t[5] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	t[6] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	t[7] = c
+	var s [8]int64
+	s[0] = int64(t[0]&0xffffffff) + int64(t[4]&0xffffffff) + int64(t[4]>>32) - int64(t[5]>>32) - int64(t[6]&0xffffffff) - int64(t[6]>>32) - int64(t[7]&0xffffffff)
+	s[1] = int64(t[0]>>32) + int64(t[4]>>32) + int64(t[5]&0xffffffff) - int64(t[6]&0xffffffff) - int64(t[6]>>32) - int64(t[7]&0xffffffff) - int64(t[7]>>32)
+	s[2] = int64(t[1]&0xffffffff) + int64(t[5]&0xffffffff) + int64(t[5]>>32) - int64(t[6]>>32) - int64(t[7]&0xffffffff) - int64(t[7]>>32)
+	s[3] = int64(t[1]>>32) - int64(t[4]&0xffffffff) - int64(t[4]>>32) + 2*int64(t[5]>>32) + 2*int64(t[6]&0xffffffff) + int64(t[6]>>32) - int64(t[7]>>32)
+	s[4] = int64(t[2]&0xffffffff) - int64(t[4]>>32) - int64(t[5]&0xffffffff) + 2*int64(t[6]&0xffffffff) + 2*int64(t[6]>>32) + int64(t[7]&0xffffffff)
+	s[5] = int64(t[2]>>32) - int64(t[5]&0xffffffff) - int64(t[5]>>32) + 2*int64(t[6]>>32) + 2*int64(t[7]&0xffffffff) + int64(t[7]>>32)
+	s[6] = int64(t[3]&0xffffffff) - int64(t[4]&0xffffffff) - int64(t[4]>>32) + int64(t[6]>>32) + 3*int64(t[7]&0xffffffff) + 2*int64(t[7]>>32)
+	s[7] = int64(t[3]>>32) + int64(t[4]&0xffffffff) - int64(t[5]&0xffffffff) - int64(t[5]>>32) - int64(t[6]&0xffffffff) - int64(t[6]>>32) + 3*int64(t[7]>>32)
+	solinasNormalize(z, s[:], []int64{1, 0, 0, -1, 0, 0, -1, 1}, p[:])
+}
+
+// mulP256K1 sets z = x*y mod 2^256-2^32-977 using pseudo-Mersenne reduction.
+func mulP256K1(z, x, y []uint64) {
+	p := [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	var t [8]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	t[4] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	t[5] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	t[6] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	t[7] = c
+	var u0 [5]uint64
+	c = 0
+	hi, lo = mulAdd(t[4], 0x1000003d1, t[0], c)
+	u0[0], c = lo, hi
+	hi, lo = mulAdd(t[5], 0x1000003d1, t[1], c)
+	u0[1], c = lo, hi
+	hi, lo = mulAdd(t[6], 0x1000003d1, t[2], c)
+	u0[2], c = lo, hi
+	hi, lo = mulAdd(t[7], 0x1000003d1, t[3], c)
+	u0[3], c = lo, hi
+	u0[4] = c
+	var u1 [5]uint64
+	c = 0
+	hi, lo = mulAdd(u0[4], 0x1000003d1, u0[0], c)
+	u1[0], c = lo, hi
+	hi, lo = mulAdd(0, 0x1000003d1, u0[1], c)
+	u1[1], c = lo, hi
+	hi, lo = mulAdd(0, 0x1000003d1, u0[2], c)
+	u1[2], c = lo, hi
+	hi, lo = mulAdd(0, 0x1000003d1, u0[3], c)
+	u1[3], c = lo, hi
+	u1[4] = c
+	copy(z, u1[:4])
+	condSub(z, u1[4], p[:])
+}
+
+// mulP384 sets z = x*y mod 2^384-2^128-2^96+2^32-1 using Solinas reduction.
+func mulP384(z, x, y []uint64) {
+	p := [6]uint64{0xffffffff, 0xffffffff00000000, 0xfffffffffffffffe, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	var t [12]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[0], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[0], t[5], c)
+	t[5], c = lo, hi
+	t[6] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[4], y[1], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[5], y[1], t[6], c)
+	t[6], c = lo, hi
+	t[7] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[4], y[2], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[5], y[2], t[7], c)
+	t[7], c = lo, hi
+	t[8] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[4], y[3], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[5], y[3], t[8], c)
+	t[8], c = lo, hi
+	t[9] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[4], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[1], y[4], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[2], y[4], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[3], y[4], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[4], y[4], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[5], y[4], t[9], c)
+	t[9], c = lo, hi
+	t[10] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[5], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[1], y[5], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[2], y[5], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[3], y[5], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[4], y[5], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[5], y[5], t[10], c)
+	t[10], c = lo, hi
+	t[11] = c
+	var s [12]int64
+	s[0] = int64(t[0]&0xffffffff) + int64(t[6]&0xffffffff) + int64(t[10]&0xffffffff) + int64(t[10]>>32) - int64(t[11]>>32)
+	s[1] = int64(t[0]>>32) - int64(t[6]&0xffffffff) + int64(t[6]>>32) - int64(t[10]&0xffffffff) + int64(t[11]&0xffffffff) + int64(t[11]>>32)
+	s[2] = int64(t[1]&0xffffffff) - int64(t[6]>>32) + int64(t[7]&0xffffffff) - int64(t[10]>>32) + int64(t[11]>>32)
+	s[3] = int64(t[1]>>32) + int64(t[6]&0xffffffff) - int64(t[7]&0xffffffff) + int64(t[7]>>32) + int64(t[10]&0xffffffff) + int64(t[10]>>32) - int64(t[11]&0xffffffff) - int64(t[11]>>32)
+	s[4] = int64(t[2]&0xffffffff) + int64(t[6]&0xffffffff) + int64(t[6]>>32) - int64(t[7]>>32) + int64(t[8]&0xffffffff) + int64(t[10]&0xffffffff) + 2*int64(t[10]>>32) + int64(t[11]&0xffffffff) - 2*int64(t[11]>>32)
+	s[5] = int64(t[2]>>32) + int64(t[6]>>32) + int64(t[7]&0xffffffff) - int64(t[8]&0xffffffff) + int64(t[8]>>32) + int64(t[10]>>32) + 2*int64(t[11]&0xffffffff) + int64(t[11]>>32)
+	s[6] = int64(t[3]&0xffffffff) + int64(t[7]&0xffffffff) + int64(t[7]>>32) - int64(t[8]>>32) + int64(t[9]&0xffffffff) + int64(t[11]&0xffffffff) + 2*int64(t[11]>>32)
+	s[7] = int64(t[3]>>32) + int64(t[7]>>32) + int64(t[8]&0xffffffff) - int64(t[9]&0xffffffff) + int64(t[9]>>32) + int64(t[11]>>32)
+	s[8] = int64(t[4]&0xffffffff) + int64(t[8]&0xffffffff) + int64(t[8]>>32) - int64(t[9]>>32) + int64(t[10]&0xffffffff)
+	s[9] = int64(t[4]>>32) + int64(t[8]>>32) + int64(t[9]&0xffffffff) - int64(t[10]&0xffffffff) + int64(t[10]>>32)
+	s[10] = int64(t[5]&0xffffffff) + int64(t[9]&0xffffffff) + int64(t[9]>>32) - int64(t[10]>>32) + int64(t[11]&0xffffffff)
+	s[11] = int64(t[5]>>32) + int64(t[9]>>32) + int64(t[10]&0xffffffff) - int64(t[11]&0xffffffff) + int64(t[11]>>32)
+	solinasNormalize(z, s[:], []int64{1, -1, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0}, p[:])
+}
+
+// mulP448 sets z = x*y mod 2^448-2^224-1 using Solinas reduction.
+func mulP448(z, x, y []uint64) {
+	p := [7]uint64{0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xfffffffeffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	var t [14]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[0], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[0], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[6], y[0], t[6], c)
+	t[6], c = lo, hi
+	t[7] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[4], y[1], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[5], y[1], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[6], y[1], t[7], c)
+	t[7], c = lo, hi
+	t[8] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[4], y[2], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[5], y[2], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[6], y[2], t[8], c)
+	t[8], c = lo, hi
+	t[9] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[4], y[3], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[5], y[3], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[6], y[3], t[9], c)
+	t[9], c = lo, hi
+	t[10] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[4], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[1], y[4], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[2], y[4], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[3], y[4], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[4], y[4], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[5], y[4], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[6], y[4], t[10], c)
+	t[10], c = lo, hi
+	t[11] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[5], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[1], y[5], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[2], y[5], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[3], y[5], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[4], y[5], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[5], y[5], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[6], y[5], t[11], c)
+	t[11], c = lo, hi
+	t[12] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[6], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[1], y[6], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[2], y[6], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[3], y[6], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[4], y[6], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[5], y[6], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[6], y[6], t[12], c)
+	t[12], c = lo, hi
+	t[13] = c
+	var s [14]int64
+	s[0] = int64(t[0]&0xffffffff) + int64(t[7]&0xffffffff) + int64(t[10]>>32)
+	s[1] = int64(t[0]>>32) + int64(t[7]>>32) + int64(t[11]&0xffffffff)
+	s[2] = int64(t[1]&0xffffffff) + int64(t[8]&0xffffffff) + int64(t[11]>>32)
+	s[3] = int64(t[1]>>32) + int64(t[8]>>32) + int64(t[12]&0xffffffff)
+	s[4] = int64(t[2]&0xffffffff) + int64(t[9]&0xffffffff) + int64(t[12]>>32)
+	s[5] = int64(t[2]>>32) + int64(t[9]>>32) + int64(t[13]&0xffffffff)
+	s[6] = int64(t[3]&0xffffffff) + int64(t[10]&0xffffffff) + int64(t[13]>>32)
+	s[7] = int64(t[3]>>32) + int64(t[7]&0xffffffff) + 2*int64(t[10]>>32)
+	s[8] = int64(t[4]&0xffffffff) + int64(t[7]>>32) + 2*int64(t[11]&0xffffffff)
+	s[9] = int64(t[4]>>32) + int64(t[8]&0xffffffff) + 2*int64(t[11]>>32)
+	s[10] = int64(t[5]&0xffffffff) + int64(t[8]>>32) + 2*int64(t[12]&0xffffffff)
+	s[11] = int64(t[5]>>32) + int64(t[9]&0xffffffff) + 2*int64(t[12]>>32)
+	s[12] = int64(t[6]&0xffffffff) + int64(t[9]>>32) + 2*int64(t[13]&0xffffffff)
+	s[13] = int64(t[6]>>32) + int64(t[10]&0xffffffff) + 2*int64(t[13]>>32)
+	solinasNormalize(z, s[:], []int64{1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}, p[:])
+}
+
+// mulP521 sets z = x*y mod 2^521-1 using pseudo-Mersenne reduction.
+func mulP521(z, x, y []uint64) {
+	p := [9]uint64{0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0x1ff}
+	var t [18]uint64
+	var c, hi, lo uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[0], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[0], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[6], y[0], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[7], y[0], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[8], y[0], t[8], c)
+	t[8], c = lo, hi
+	t[9] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[4], y[1], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[5], y[1], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[6], y[1], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[7], y[1], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[8], y[1], t[9], c)
+	t[9], c = lo, hi
+	t[10] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[4], y[2], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[5], y[2], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[6], y[2], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[7], y[2], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[8], y[2], t[10], c)
+	t[10], c = lo, hi
+	t[11] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[4], y[3], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[5], y[3], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[6], y[3], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[7], y[3], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[8], y[3], t[11], c)
+	t[11], c = lo, hi
+	t[12] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[4], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[1], y[4], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[2], y[4], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[3], y[4], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[4], y[4], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[5], y[4], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[6], y[4], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[7], y[4], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[8], y[4], t[12], c)
+	t[12], c = lo, hi
+	t[13] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[5], t[5], c)
+	t[5], c = lo, hi
+	hi, lo = mulAdd(x[1], y[5], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[2], y[5], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[3], y[5], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[4], y[5], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[5], y[5], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[6], y[5], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[7], y[5], t[12], c)
+	t[12], c = lo, hi
+	hi, lo = mulAdd(x[8], y[5], t[13], c)
+	t[13], c = lo, hi
+	t[14] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[6], t[6], c)
+	t[6], c = lo, hi
+	hi, lo = mulAdd(x[1], y[6], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[2], y[6], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[3], y[6], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[4], y[6], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[5], y[6], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[6], y[6], t[12], c)
+	t[12], c = lo, hi
+	hi, lo = mulAdd(x[7], y[6], t[13], c)
+	t[13], c = lo, hi
+	hi, lo = mulAdd(x[8], y[6], t[14], c)
+	t[14], c = lo, hi
+	t[15] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[7], t[7], c)
+	t[7], c = lo, hi
+	hi, lo = mulAdd(x[1], y[7], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[2], y[7], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[3], y[7], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[4], y[7], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[5], y[7], t[12], c)
+	t[12], c = lo, hi
+	hi, lo = mulAdd(x[6], y[7], t[13], c)
+	t[13], c = lo, hi
+	hi, lo = mulAdd(x[7], y[7], t[14], c)
+	t[14], c = lo, hi
+	hi, lo = mulAdd(x[8], y[7], t[15], c)
+	t[15], c = lo, hi
+	t[16] = c
+	c = 0
+	hi, lo = mulAdd(x[0], y[8], t[8], c)
+	t[8], c = lo, hi
+	hi, lo = mulAdd(x[1], y[8], t[9], c)
+	t[9], c = lo, hi
+	hi, lo = mulAdd(x[2], y[8], t[10], c)
+	t[10], c = lo, hi
+	hi, lo = mulAdd(x[3], y[8], t[11], c)
+	t[11], c = lo, hi
+	hi, lo = mulAdd(x[4], y[8], t[12], c)
+	t[12], c = lo, hi
+	hi, lo = mulAdd(x[5], y[8], t[13], c)
+	t[13], c = lo, hi
+	hi, lo = mulAdd(x[6], y[8], t[14], c)
+	t[14], c = lo, hi
+	hi, lo = mulAdd(x[7], y[8], t[15], c)
+	t[15], c = lo, hi
+	hi, lo = mulAdd(x[8], y[8], t[16], c)
+	t[16], c = lo, hi
+	t[17] = c
+	var u0 [11]uint64
+	c = 0
+	hi, lo = mulAdd(t[8]>>9|t[9]<<55, 0x1, t[0], c)
+	u0[0], c = lo, hi
+	hi, lo = mulAdd(t[9]>>9|t[10]<<55, 0x1, t[1], c)
+	u0[1], c = lo, hi
+	hi, lo = mulAdd(t[10]>>9|t[11]<<55, 0x1, t[2], c)
+	u0[2], c = lo, hi
+	hi, lo = mulAdd(t[11]>>9|t[12]<<55, 0x1, t[3], c)
+	u0[3], c = lo, hi
+	hi, lo = mulAdd(t[12]>>9|t[13]<<55, 0x1, t[4], c)
+	u0[4], c = lo, hi
+	hi, lo = mulAdd(t[13]>>9|t[14]<<55, 0x1, t[5], c)
+	u0[5], c = lo, hi
+	hi, lo = mulAdd(t[14]>>9|t[15]<<55, 0x1, t[6], c)
+	u0[6], c = lo, hi
+	hi, lo = mulAdd(t[15]>>9|t[16]<<55, 0x1, t[7], c)
+	u0[7], c = lo, hi
+	hi, lo = mulAdd(t[16]>>9|t[17]<<55, 0x1, t[8]&0x1ff, c)
+	u0[8], c = lo, hi
+	hi, lo = mulAdd(t[17]>>9, 0x1, 0, c)
+	u0[9], c = lo, hi
+	u0[10] = c
+	var u1 [10]uint64
+	c = 0
+	hi, lo = mulAdd(u0[8]>>9|u0[9]<<55, 0x1, u0[0], c)
+	u1[0], c = lo, hi
+	hi, lo = mulAdd(u0[9]>>9|u0[10]<<55, 0x1, u0[1], c)
+	u1[1], c = lo, hi
+	hi, lo = mulAdd(u0[10]>>9, 0x1, u0[2], c)
+	u1[2], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[3], c)
+	u1[3], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[4], c)
+	u1[4], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[5], c)
+	u1[5], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[6], c)
+	u1[6], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[7], c)
+	u1[7], c = lo, hi
+	hi, lo = mulAdd(0, 0x1, u0[8]&0x1ff, c)
+	u1[8], c = lo, hi
+	u1[9] = c
+	copy(z, u1[:9])
+	condSub(z, u1[9], p[:])
+}
+
+// mulBLS12381 sets z = x*y mod 0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab using Montgomery multiplication.
+func mulBLS12381(z, x, y []uint64) {
+	p := [6]uint64{0xb9feffffffffaaab, 0x1eabfffeb153ffff, 0x6730d2a0f6b0f624, 0x64774b84f38512bf, 0x4b1ba7b6434bacd7, 0x1a0111ea397fe69a}
+	var t [8]uint64
+	var c, hi, lo, m uint64
+	c = 0
+	hi, lo = mulAdd(x[0], y[0], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[0], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[0], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[0], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[0], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[0], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	c = 0
+	hi, lo = mulAdd(x[0], y[1], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[1], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[1], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[1], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[1], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[1], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	c = 0
+	hi, lo = mulAdd(x[0], y[2], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[2], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[2], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[2], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[2], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[2], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	c = 0
+	hi, lo = mulAdd(x[0], y[3], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[3], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[3], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[3], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[3], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[3], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	c = 0
+	hi, lo = mulAdd(x[0], y[4], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[4], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[4], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[4], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[4], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[4], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	c = 0
+	hi, lo = mulAdd(x[0], y[5], t[0], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(x[1], y[5], t[1], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(x[2], y[5], t[2], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(x[3], y[5], t[3], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(x[4], y[5], t[4], c)
+	t[4], c = lo, hi
+	hi, lo = mulAdd(x[5], y[5], t[5], c)
+	t[5], c = lo, hi
+	t[6], t[7] = bits.Add64(t[6], c, 0)
+	m = t[0] * 0x89f3fffcfffcfffd
+	c, _ = mulAdd(m, 0xb9feffffffffaaab, t[0], 0)
+	hi, lo = mulAdd(m, 0x1eabfffeb153ffff, t[1], c)
+	t[0], c = lo, hi
+	hi, lo = mulAdd(m, 0x6730d2a0f6b0f624, t[2], c)
+	t[1], c = lo, hi
+	hi, lo = mulAdd(m, 0x64774b84f38512bf, t[3], c)
+	t[2], c = lo, hi
+	hi, lo = mulAdd(m, 0x4b1ba7b6434bacd7, t[4], c)
+	t[3], c = lo, hi
+	hi, lo = mulAdd(m, 0x1a0111ea397fe69a, t[5], c)
+	t[4], c = lo, hi
+	t[5], c = bits.Add64(t[6], c, 0)
+	t[6] = t[7] + c
+	copy(z, t[:6])
+	condSub(z, t[6], p[:])
+}
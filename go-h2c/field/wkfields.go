@@ -1,5 +1,7 @@
 package field
 
+import "fmt"
+
 // ID is an identifier of a well-known prime modulus.
 type ID int
 
@@ -18,6 +20,8 @@ const (
 	P521
 	// BLS12381 is a 381-bit prime,
 	BLS12381
+	// BLS12381Fp2 is the quadratic extension of BLS12381 given by i^2+1=0.
+	BLS12381Fp2
 )
 
 func (id ID) String() string {
@@ -36,30 +40,69 @@ func (id ID) String() string {
 		return "2^521-1"
 	case BLS12381:
 		return "BLS12381"
+	case BLS12381Fp2:
+		return "BLS12381Fp2"
 	default:
 		return ""
 	}
 }
 
+// Backend is an identifier of an implementation of prime fields.
+type Backend int
+
+const (
+	// BigInt is the default implementation, it is based on math/big and its
+	// running time depends on the values of the elements.
+	BigInt Backend = iota
+	// ConstantTime is an implementation based on fixed-size limbs and
+	// Montgomery multiplication, its running time does not depend on the
+	// values of the elements.
+	ConstantTime
+	// Specialized is like ConstantTime but it uses the arithmetic generated
+	// for each well-known prime.
+	Specialized
+)
+
 // Get returns an implementation of a field corresponding to the identifier.
-func (id ID) Get() Field {
+// It panics if the field is not supported, see TryGet.
+func (id ID) Get() Field { return id.GetBackend(BigInt) }
+
+// TryGet returns an implementation of a field corresponding to the
+// identifier, or ErrUnsupported if the field is not supported.
+func (id ID) TryGet() (Field, error) { return id.TryGetBackend(BigInt) }
+
+// GetBackend is like Get but the field is implemented by the backend b.
+func (id ID) GetBackend(b Backend) Field { return mustField(id.TryGetBackend(b)) }
+
+// TryGetBackend is like TryGet but the field is implemented by the backend b.
+// Extension fields always use the BigInt backend.
+func (id ID) TryGetBackend(b Backend) (Field, error) {
+	newFp := NewFpE
+	switch b {
+	case ConstantTime:
+		newFp = NewFpCTE
+	case Specialized:
+		newFp = newFpSpecialized
+	}
 	switch id {
 	case P25519:
-		return NewFp(id, "57896044618658097711785492504343953926634992332820282019728792003956564819949")
+		return newFp(id, "57896044618658097711785492504343953926634992332820282019728792003956564819949")
 	case P256:
-		return NewFp(id, "115792089210356248762697446949407573530086143415290314195533631308867097853951")
+		return newFp(id, "115792089210356248762697446949407573530086143415290314195533631308867097853951")
 	case P256K1:
-		return NewFp(id, "115792089237316195423570985008687907853269984665640564039457584007908834671663")
+		return newFp(id, "115792089237316195423570985008687907853269984665640564039457584007908834671663")
 	case P384:
-		return NewFp(id, "39402006196394479212279040100143613805079739270465446667948293404245721771496870329047266088258938001861606973112319")
+		return newFp(id, "39402006196394479212279040100143613805079739270465446667948293404245721771496870329047266088258938001861606973112319")
 	case P448:
-		return NewFp(id, "726838724295606890549323807888004534353641360687318060281490199180612328166730772686396383698676545930088884461843637361053498018365439")
+		return newFp(id, "726838724295606890549323807888004534353641360687318060281490199180612328166730772686396383698676545930088884461843637361053498018365439")
 	case P521:
-		return NewFp(id, "6864797660130609714981900799081393217269435300143305409394463459185543183397656052122559640661454554977296311391480858037121987999716643812574028291115057151")
+		return newFp(id, "6864797660130609714981900799081393217269435300143305409394463459185543183397656052122559640661454554977296311391480858037121987999716643812574028291115057151")
 	case BLS12381:
-		return NewFp(id, "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+		return newFp(id, "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
+	case BLS12381Fp2:
+		return NewFp2E(id.String(), "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab")
 	default:
-		panic("field not supported")
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, int(id))
 	}
 }
 
@@ -1,12 +1,6 @@
 package h2c
 
 import (
-	"hash"
-	"io"
-	"math/big"
-
-	"golang.org/x/crypto/hkdf"
-
 	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 	M "github.com/armfazh/hash-to-curve-ref/go-h2c/mapping"
@@ -14,71 +8,90 @@ import (
 
 // HashToPoint represents a complete and secure function for hashing strings to points.
 type HashToPoint interface {
+	// FieldHasher hashes strings into elements of the field of definition of
+	// the curve, using the same parameters as Hash.
+	FieldHasher
 	// IsRandomOracle returns true if the output distribution is
 	// indifferentiable from a random oracle.
 	IsRandomOracle() bool
 	// Hash returns a point on an elliptic curve given as input a string and a
-	// domain separation tag.
+	// domain separation tag. Tags longer than 255 bytes are reduced as
+	// specified in RFC 9380 (Section 5.3.3); Hash panics if the tag is empty.
 	Hash(in, dst []byte) C.Point
+	// HashE is like Hash but it returns an error if hashing fails, for
+	// example, if the tag is empty.
+	HashE(in, dst []byte) (C.Point, error)
+	// HashToScalar returns an element of the scalar field of the curve, i.e.,
+	// an integer modulo the order of the prime-order subgroup, given as input
+	// a string and a domain separation tag. It uses the same expander and
+	// security level as Hash. HashToScalar panics if the tag is empty.
+	HashToScalar(in, dst []byte) GF.Elt
+	// HashToScalarE is like HashToScalar but it returns an error if hashing
+	// fails, for example, if the tag is empty.
+	HashToScalarE(in, dst []byte) (GF.Elt, error)
+	// GetScalarField returns the scalar field of the curve.
+	GetScalarField() GF.Field
 	// GetCurve returns the destination elliptic curve.
 	GetCurve() C.EllCurve
 }
 
 type encoding struct {
-	E            C.EllCurve
-	HFunc        func() hash.Hash
-	L            uint
+	E C.EllCurve
+	FieldHasher
+	Scalar       FieldHasher
 	Mapping      M.MapToCurve
 	RandomOracle bool
 }
 
-// hashToField is a function that hashes a string msg of any length into an
-// element of a finite field.
-func (e *encoding) hashToField(
-	msg []byte, // msg is the message to hash.
-	dst []byte, // DST, a domain separation tag (see discussion above).
-	ctr byte, // ctr is 0, 1, or 2.
-) GF.Elt {
-	info := []byte{'H', '2', 'C', ctr, byte(1)}
-	msgPrime := hkdf.Extract(e.HFunc, append(msg, byte(0)), dst)
-
-	F := e.E.Field()
-	m := F.Ext()
-	v := make([]interface{}, m)
-	t := make([]byte, e.L)
-
-	for i := uint(1); i <= m; i++ {
-		info[4] = byte(i)
-		rd := hkdf.Expand(e.HFunc, msgPrime, info)
-		if _, err := io.ReadFull(rd, t); err != nil {
-			panic("error on hdkf")
-		}
-		vi := new(big.Int).SetBytes(t)
-		v[i-1] = vi.Mod(vi, F.P())
+func (e *encoding) GetCurve() C.EllCurve     { return e.E }
+func (e *encoding) GetScalarField() GF.Field { return e.Scalar.GetField() }
+func (e *encoding) IsRandomOracle() bool     { return e.RandomOracle }
+func (e *encoding) HashToScalar(in, dst []byte) GF.Elt {
+	k, err := e.HashToScalarE(in, dst)
+	if err != nil {
+		panic(err)
 	}
-	return F.Elt(v)
+	return k
+}
+func (e *encoding) HashToScalarE(in, dst []byte) (GF.Elt, error) {
+	k, err := e.Scalar.HashToFieldE(in, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return k[0], nil
 }
 
-func (e *encoding) GetCurve() C.EllCurve { return e.E }
-func (e *encoding) IsRandomOracle() bool { return e.RandomOracle }
+func mustHash(P C.Point, err error) C.Point {
+	if err != nil {
+		panic(err)
+	}
+	return P
+}
 
 type encodeToCurve struct{ *encoding }
 
-func (s *encodeToCurve) Hash(in, dst []byte) C.Point {
-	u := s.hashToField(in, dst, byte(2))
-	Q := s.Mapping.Map(u)
+func (s *encodeToCurve) Hash(in, dst []byte) C.Point { return mustHash(s.HashE(in, dst)) }
+func (s *encodeToCurve) HashE(in, dst []byte) (C.Point, error) {
+	u, err := s.HashToFieldE(in, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	Q := s.Mapping.Map(u[0])
 	P := s.E.ClearCofactor(Q)
-	return P
+	return P, nil
 }
 
 type hashToCurve struct{ *encoding }
 
-func (s *hashToCurve) Hash(in, dst []byte) C.Point {
-	u0 := s.hashToField(in, dst, byte(0))
-	u1 := s.hashToField(in, dst, byte(1))
-	Q0 := s.Mapping.Map(u0)
-	Q1 := s.Mapping.Map(u1)
+func (s *hashToCurve) Hash(in, dst []byte) C.Point { return mustHash(s.HashE(in, dst)) }
+func (s *hashToCurve) HashE(in, dst []byte) (C.Point, error) {
+	u, err := s.HashToFieldE(in, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	Q0 := s.Mapping.Map(u[0])
+	Q1 := s.Mapping.Map(u[1])
 	R := s.E.Add(Q0, Q1)
 	P := s.E.ClearCofactor(R)
-	return P
+	return P, nil
 }
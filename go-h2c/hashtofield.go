@@ -0,0 +1,149 @@
+package h2c
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// FieldHasher represents a function for hashing strings to elements of a
+// finite field, as defined by hash_to_field in RFC 9380 (Section 5).
+type FieldHasher interface {
+	// HashToField returns count elements of a finite field given as input a
+	// string and a domain separation tag. It panics if hashing fails, for
+	// example, if the tag is empty.
+	HashToField(msg, dst []byte, count int) []GF.Elt
+	// HashToFieldE is like HashToField but it returns an error if hashing
+	// fails.
+	HashToFieldE(msg, dst []byte, count int) ([]GF.Elt, error)
+	// GetField returns the destination finite field.
+	GetField() GF.Field
+}
+
+// NewFieldHasherXMD returns a FieldHasher into the field f targeting k bits
+// of security, which expands messages using expand_message_xmd with h.
+func NewFieldHasherXMD(f GF.Field, h crypto.Hash, k uint) FieldHasher {
+	return newFieldHasher(f, xmd{h}, k)
+}
+
+// NewFieldHasherXOF returns a FieldHasher into the field f targeting k bits
+// of security, which expands messages using expand_message_xof with x.
+func NewFieldHasherXOF(f GF.Field, x XOF, k uint) FieldHasher {
+	return newFieldHasher(f, xof{x, k}, k)
+}
+
+type fieldHasher struct {
+	F   GF.Field
+	Exp expander
+	L   uint
+}
+
+func newFieldHasher(f GF.Field, exp expander, k uint) *fieldHasher {
+	// L = ceil((ceil(log2(p)) + k) / 8)
+	L := (uint(f.P().BitLen()) + k + 7) / 8
+	return &fieldHasher{F: f, Exp: exp, L: L}
+}
+
+func (h *fieldHasher) GetField() GF.Field { return h.F }
+
+func (h *fieldHasher) HashToField(msg, dst []byte, count int) []GF.Elt {
+	return mustHashToField(h.HashToFieldE(msg, dst, count))
+}
+
+func mustHashToField(u []GF.Elt, err error) []GF.Elt {
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// HashToFieldE hashes a string msg of any length into count elements of a
+// finite field. It returns an error if the message cannot be expanded.
+func (h *fieldHasher) HashToFieldE(
+	msg []byte, // msg is the message to hash.
+	dst []byte, // DST, a domain separation tag.
+	count int, // count is the number of field elements to output.
+) ([]GF.Elt, error) {
+	F := h.F
+	m := int(F.Ext())
+	L := int(h.L)
+	uniform, err := h.Exp.expand(msg, dst, count*m*L)
+	if err != nil {
+		return nil, err
+	}
+
+	u := make([]GF.Elt, count)
+	v := make([]interface{}, m)
+	p := F.P()
+	for i := 0; i < count; i++ {
+		for j := 0; j < m; j++ {
+			offset := L * (j + i*m)
+			t := uniform[offset : offset+L]
+			vj := new(big.Int).SetBytes(t)
+			v[j] = vj.Mod(vj, p)
+		}
+		u[i] = F.Elt(v)
+	}
+	return u, nil
+}
+
+// fieldHasherDraft05 implements the HKDF-based hash_to_field function of
+// draft-irtf-cfrg-hash-to-curve-05, which is only used by deprecated suites.
+type fieldHasherDraft05 struct {
+	F  GF.Field
+	H  crypto.Hash
+	L  uint
+	RO bool
+}
+
+func (h *fieldHasherDraft05) GetField() GF.Field { return h.F }
+
+func (h *fieldHasherDraft05) HashToField(msg, dst []byte, count int) []GF.Elt {
+	return mustHashToField(h.HashToFieldE(msg, dst, count))
+}
+
+// HashToFieldE returns count elements of the field. Random-oracle suites use
+// counters 0, 1, ..., and nonuniform suites use the counter 2.
+func (h *fieldHasherDraft05) HashToFieldE(msg, dst []byte, count int) ([]GF.Elt, error) {
+	if len(dst) == 0 {
+		return nil, ErrEmptyDST
+	}
+	u := make([]GF.Elt, count)
+	for i := range u {
+		ctr := byte(2)
+		if h.RO {
+			ctr = byte(i)
+		}
+		var err error
+		if u[i], err = h.hashToField(msg, dst, ctr); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func (h *fieldHasherDraft05) hashToField(msg, dst []byte, ctr byte) (GF.Elt, error) {
+	info := []byte{'H', '2', 'C', ctr, byte(1)}
+	msgPrime := hkdf.Extract(h.H.New, append(append([]byte{}, msg...), byte(0)), dst)
+
+	F := h.F
+	m := F.Ext()
+	v := make([]interface{}, m)
+	t := make([]byte, h.L)
+
+	for i := uint(1); i <= m; i++ {
+		info[4] = byte(i)
+		rd := hkdf.Expand(h.H.New, msgPrime, info)
+		if _, err := io.ReadFull(rd, t); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrLongOutput, err)
+		}
+		vi := new(big.Int).SetBytes(t)
+		v[i-1] = vi.Mod(vi, F.P())
+	}
+	return F.Elt(v), nil
+}
@@ -28,44 +28,51 @@ func initCurves() {
 	var f53 = GF.NewFp(P53, 53) // 1mod4, 2mod3
 	var f59 = GF.NewFp(P59, 59) // 3mod4, 2mod3
 
+	// As for standard curves, R is the order of a subgroup and H is the
+	// cofactor, such that #E = R*H and gcd(R, H) = 1.
+
 	registerToyCurve("W0", C.NewWeierstrass(C.Custom, f53,
-		f53.Elt(3), f53.Elt(2), big.NewInt(51), big.NewInt(3)),
+		f53.Elt(3), f53.Elt(2), big.NewInt(17), big.NewInt(3)),
 		f53.Elt(46), f53.Elt(3))
 
 	registerToyCurve("W1", C.NewWeierstrass(C.Custom, f53,
-		f53.Zero(), f53.One(), big.NewInt(54), big.NewInt(2)),
+		f53.Zero(), f53.One(), big.NewInt(27), big.NewInt(2)),
 		f53.Elt(13), f53.Elt(5))
 
 	registerToyCurve("W1iso", C.NewWeierstrass(C.Custom, f53,
-		f53.Elt(38), f53.Elt(22), big.NewInt(54), big.NewInt(2)),
+		f53.Elt(38), f53.Elt(22), big.NewInt(27), big.NewInt(2)),
 		f53.Elt(41), f53.Elt(45))
 
 	registerToyCurve("W2", C.NewWeierstrass(C.Custom, f53,
-		f53.Zero(), f53.Elt(2), big.NewInt(51), big.NewInt(3)),
+		f53.Zero(), f53.Elt(2), big.NewInt(27), big.NewInt(2)),
 		f53.Elt(37), f53.Elt(27))
 
 	registerToyCurve("W3", C.NewWeierstrass(C.Custom, f59,
-		f59.Elt(16), f59.Zero(), big.NewInt(60), big.NewInt(4)),
+		f59.Elt(16), f59.Zero(), big.NewInt(15), big.NewInt(4)),
 		f59.Elt(33), f59.Elt(11))
 
+	registerToyCurve("W4", C.NewWeierstrass(C.Custom, f59,
+		f59.One(), f59.One(), big.NewInt(7), big.NewInt(9)),
+		f59.Zero(), f59.One())
+
 	registerToyCurve("WC0", C.NewWeierstrassC(C.Custom, f53,
-		f53.Elt(2), f53.Elt(3), big.NewInt(66), big.NewInt(6)),
+		f53.Elt(2), f53.Elt(3), big.NewInt(11), big.NewInt(6)),
 		f53.Elt(45), f53.Elt(4))
 
 	registerToyCurve("M0", C.NewMontgomery(C.Custom, f53,
-		f53.Elt(4), f53.Elt(3), big.NewInt(44), big.NewInt(4)),
+		f53.Elt(4), f53.Elt(3), big.NewInt(11), big.NewInt(4)),
 		f53.Elt(16), f53.Elt(4))
 
 	registerToyCurve("M1", C.NewMontgomery(C.Custom, f53,
-		f53.Elt(3), f53.Elt(1), big.NewInt(48), big.NewInt(4)),
+		f53.Elt(3), f53.Elt(1), big.NewInt(3), big.NewInt(16)),
 		f53.Elt(14), f53.Elt(22))
 
 	registerToyCurve("E0", C.NewEdwards(C.Custom, f53,
-		f53.Elt(1), f53.Elt(3), big.NewInt(44), big.NewInt(4)),
+		f53.Elt(1), f53.Elt(3), big.NewInt(11), big.NewInt(4)),
 		f53.Elt(17), f53.Elt(49))
 
 	registerToyCurve("E1", C.NewEdwards(C.Custom, f53,
-		f53.Elt(-1), f53.Elt(12), big.NewInt(48), big.NewInt(4)),
+		f53.Elt(-1), f53.Elt(12), big.NewInt(3), big.NewInt(16)),
 		f53.Elt(3), f53.Elt(19))
 
 }
@@ -17,13 +17,22 @@ type bf struct {
 
 func (m bf) String() string { return fmt.Sprintf("Boneh-Franklin for E: %v", m.E) }
 
-// NewBF implements the Boneh-Franklin method.
-func NewBF(e C.EllCurve) MapToCurve {
-	if s := (&bf{E: e.(C.W)}); s.verify() {
+// NewBF implements the Boneh-Franklin method, it panics if the curve is not
+// supported, see NewBFE.
+func NewBF(e C.EllCurve) MapToCurve { return mustMap(NewBFE(e)) }
+
+// NewBFE implements the Boneh-Franklin method, or returns ErrInvalidCurve if
+// the curve is not supported.
+func NewBFE(e C.EllCurve) (MapToCurve, error) {
+	E, ok := e.(C.W)
+	if !ok {
+		return nil, ErrInvalidCurve
+	}
+	if s := (&bf{E: E}); s.verify() {
 		s.precmp()
-		return s
+		return s, nil
 	}
-	panic(fmt.Errorf("Failed restrictions for ell2"))
+	return nil, ErrInvalidCurve
 }
 func (m *bf) verify() bool {
 	F := m.E.F
@@ -7,18 +7,31 @@ import (
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 )
 
-// NewElligator2 implements the Elligator2 method.
+// NewElligator2 implements the Elligator2 method, it panics if the curve is
+// not supported, see NewElligator2E.
 func NewElligator2(e C.EllCurve, sgn0 GF.Sgn0ID) MapToCurve {
+	return mustMap(NewElligator2E(e, sgn0))
+}
+
+// NewElligator2E implements the Elligator2 method, or returns ErrInvalidCurve
+// if the curve is not supported.
+func NewElligator2E(e C.EllCurve, sgn0 GF.Sgn0ID) (MapToCurve, error) {
+	return newElligator2(e, sgn0, false)
+}
+
+// newElligator2 implements the Elligator2 method; if draft05 is set, the sign
+// of the y-coordinate is chosen as in draft-irtf-cfrg-hash-to-curve-05.
+func newElligator2(e C.EllCurve, sgn0 GF.Sgn0ID, draft05 bool) (MapToCurve, error) {
 	switch curve := e.(type) {
 	case C.W:
 		return newWA0Ell2(curve, sgn0)
 	case C.WC:
-		return newWCEll2(curve, sgn0)
+		return newWCEll2(curve, sgn0, draft05)
 	case C.M:
-		return newMTEll2(curve, sgn0)
+		return newMTEll2(curve, sgn0, draft05)
 	case C.T:
-		return newTEEll2(curve, sgn0)
+		return newTEEll2(curve, sgn0, draft05)
 	default:
-		panic(fmt.Errorf("Curve doesn't support an elligator2 mapping"))
+		return nil, fmt.Errorf("%w: %T has no elligator2 mapping", ErrInvalidCurve, e)
 	}
 }
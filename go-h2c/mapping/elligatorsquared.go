@@ -0,0 +1,145 @@
+package mapping
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+
+	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+// ElligatorSquared encodes points as byte strings that are indistinguishable
+// from uniformly random strings, as described by Tibouchi in "Elligator
+// Squared: Uniform Points on Elliptic Curves of Prime Order as Uniform Random
+// Strings". A point P is encoded as a pair (u1, u2) of field elements such
+// that P = Map(u1) + Map(u2), so it works for every curve with an
+// InvertibleMap, such as SVDW for Weierstrass curves and SSWU for those with
+// A*B != 0.
+type ElligatorSquared struct {
+	E C.EllCurve
+	M InvertibleMap
+	L int // L is the length in bytes of each coefficient of a field element.
+}
+
+// numBranches is the number of branches of the inverse maps.
+const numBranches = 4
+
+// maxAttempts bounds the number of values of u1 tried by EncodeE. For SSWU and
+// SVDW, an attempt succeeds with probability close to 1/4, so EncodeE fails
+// with probability about 2^-53.
+const maxAttempts = 128
+
+// NewElligatorSquared returns an encoder of points of e on top of the map m,
+// which must implement InvertibleMap. Each coefficient of a field element is
+// encoded using L = ceil((ceil(log2(p)) + k) / 8) bytes, as in hash_to_field,
+// so that encodings are at distance at most 2^-k of uniform strings. It
+// panics if m is not invertible, see NewElligatorSquaredE.
+func NewElligatorSquared(e C.EllCurve, m MapToCurve, k uint) *ElligatorSquared {
+	s, err := NewElligatorSquaredE(e, m, k)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewElligatorSquaredE is like NewElligatorSquared but it returns
+// ErrNotInvertible if m does not implement InvertibleMap.
+func NewElligatorSquaredE(e C.EllCurve, m MapToCurve, k uint) (*ElligatorSquared, error) {
+	im, ok := m.(InvertibleMap)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNotInvertible, m)
+	}
+	L := (e.Field().P().BitLen() + int(k) + 7) / 8
+	return &ElligatorSquared{e, im, L}, nil
+}
+
+// Size returns the length in bytes of the encoding of a point.
+func (s *ElligatorSquared) Size() int { return 2 * int(s.E.Field().Ext()) * s.L }
+
+// Encode returns a random encoding of P using the randomness source rnd. It
+// panics if reading from rnd fails or no encoding is found, see EncodeE.
+func (s *ElligatorSquared) Encode(P C.Point, rnd io.Reader) []byte {
+	b, err := s.EncodeE(P, rnd)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// EncodeE returns a random encoding of P using the randomness source rnd. It
+// returns the error of rnd if reading fails, or ErrNoEncoding if no encoding
+// is found after maxAttempts attempts.
+func (s *ElligatorSquared) EncodeE(P C.Point, rnd io.Reader) ([]byte, error) {
+	F := s.E.Field()
+	var b [1]byte
+	for i := 0; i < maxAttempts; i++ {
+		u1 := F.Rand(rnd)
+		Q := s.E.Add(P, s.E.Neg(s.M.Map(u1))) // Q = P - Map(u1)
+		if _, err := io.ReadFull(rnd, b[:]); err != nil {
+			return nil, err
+		}
+		// Choosing a branch at random and retrying if it has no preimage
+		// samples u2 with probability proportional to the number of
+		// preimages of Q, so (u1, u2) is uniform among encodings of P.
+		if u2, ok := s.M.Inverse(Q, int(b[0])%numBranches); ok {
+			return append(s.encodeElt(u1, rnd), s.encodeElt(u2, rnd)...), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", ErrNoEncoding, P)
+}
+
+// Decode returns the point encoded by the byte string b, it panics if b has
+// not the length of an encoding, see DecodeE.
+func (s *ElligatorSquared) Decode(b []byte) C.Point {
+	P, err := s.DecodeE(b)
+	if err != nil {
+		panic(err)
+	}
+	return P
+}
+
+// DecodeE returns the point encoded by the byte string b, or
+// ErrInvalidEncoding if b has not the length of an encoding.
+func (s *ElligatorSquared) DecodeE(b []byte) (C.Point, error) {
+	if len(b) != s.Size() {
+		return nil, fmt.Errorf("%w: length %v", ErrInvalidEncoding, len(b))
+	}
+	n := len(b) / 2
+	u1, u2 := s.decodeElt(b[:n]), s.decodeElt(b[n:])
+	return s.E.Add(s.M.Map(u1), s.M.Map(u2)), nil
+}
+
+// encodeElt encodes each coefficient c of x as c + r*p, for r chosen at random
+// such that c + r*p < 2^(8L).
+func (s *ElligatorSquared) encodeElt(x GF.Elt, rnd io.Reader) []byte {
+	F := s.E.Field()
+	p := F.P()
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(8*s.L))
+	out := make([]byte, 0, int(F.Ext())*s.L)
+	for _, c := range F.Coeffs(x) {
+		n := new(big.Int).Sub(bound, c)
+		n.Sub(n, big.NewInt(1))
+		n.Div(n, p)
+		n.Add(n, big.NewInt(1)) // number of lifts c + r*p below 2^(8L)
+		r, err := rand.Int(rnd, n)
+		if err != nil {
+			panic(err)
+		}
+		c = r.Mul(r, p).Add(r, c)
+		out = append(out, c.FillBytes(make([]byte, s.L))...)
+	}
+	return out
+}
+
+func (s *ElligatorSquared) decodeElt(b []byte) GF.Elt {
+	F := s.E.Field()
+	p := F.P()
+	v := make([]interface{}, F.Ext())
+	for i := range v {
+		c := new(big.Int).SetBytes(b[i*s.L : (i+1)*s.L])
+		v[i] = c.Mod(c, p)
+	}
+	return F.Elt(v)
+}
@@ -0,0 +1,16 @@
+package mapping
+
+import (
+	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
+)
+
+type sswuReference struct{ *sswu }
+
+func (m sswuReference) Map(u GF.Elt) C.Point { return m.mapReference(u) }
+
+// NewSSWUReference returns the Simplified SWU map that computes inv0,
+// is_square and sqrt separately, it is used to test the straight-line map.
+func NewSSWUReference(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID) MapToCurve {
+	return sswuReference{mustMap(newSSWU(e, z, sgn0)).(*sswu)}
+}
@@ -2,28 +2,59 @@
 // a field element and return a point on an elliptic curve. Certain mappings
 // restrict the form of the curve or its parameters.
 //
-// Choosing a mapping function
+// # Choosing a mapping function
 //
 // If the target elliptic curve is:
-//  - a supersingular curve, then use either the Boneh-Franklin method (NewBF) or the Elligator 2 method for A == 0 (newWA0Ell2);
-//  - a Montgomery or twisted Edwards curve, then use the Elligator 2 (NewElligator2);
-//  - a Weierstrass curve, then use either the Simplified SWU (NewSSWU), even if either A or B is zero;
-//  - if none of the above applies, then use the Shallue-van de Woestijne method (NewSVDW).
+//   - a supersingular curve, then use either the Boneh-Franklin method (NewBF) or the Elligator 2 method for A == 0 (newWA0Ell2);
+//   - a Montgomery or twisted Edwards curve, then use the Elligator 2 (NewElligator2);
+//   - a Weierstrass curve, then use either the Simplified SWU (NewSSWU), even if either A or B is zero;
+//   - if none of the above applies, then use the Shallue-van de Woestijne method (NewSVDW).
 //
 // Note: the mappings must not be used standalone, since its correct and secure
 // usage is determined by each hash to curve suite.
 package mapping
 
 import (
+	"errors"
+	"fmt"
+
 	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 )
 
+// Errors returned by the constructors of mappings.
+var (
+	// ErrUnsupported is returned for mappings with no implementation.
+	ErrUnsupported = errors.New("mapping: mapping not supported")
+	// ErrInvalidCurve is returned when the curve does not satisfy the
+	// conditions required by the mapping.
+	ErrInvalidCurve = errors.New("mapping: curve not supported by the mapping")
+	// ErrInvalidZ is returned when the constant Z does not satisfy the
+	// conditions required by the mapping.
+	ErrInvalidZ = errors.New("mapping: invalid constant Z")
+	// ErrNotInvertible is returned when a mapping has no inverse map.
+	ErrNotInvertible = errors.New("mapping: mapping is not invertible")
+	// ErrInvalidEncoding is returned when an encoding of a point is malformed.
+	ErrInvalidEncoding = errors.New("mapping: invalid encoding")
+	// ErrNoEncoding is returned when no encoding of a point is found.
+	ErrNoEncoding = errors.New("mapping: no encoding found")
+)
+
 // MapToCurve maps a field element into a elliptic curve point.
 type MapToCurve interface {
 	Map(GF.Elt) C.Point
 }
 
+// InvertibleMap is a MapToCurve that can compute preimages of points, which
+// allows to encode points as uniformly-distributed field elements.
+type InvertibleMap interface {
+	MapToCurve
+	// Inverse returns a field element u such that Map(u) = P. A point has
+	// several preimages, so branch (an integer from 0 to 3) selects which one
+	// is computed. It returns false if no preimage exists for this branch.
+	Inverse(P C.Point, branch int) (GF.Elt, bool)
+}
+
 // ID is an identifier of a mapping.
 type ID uint
 
@@ -38,21 +69,48 @@ const (
 	EDELL2
 	// SVDW is Shallue-van de Woestijne method.
 	SVDW
+	// ELL2Draft05 is Elligator2 method for Montgomery curves as specified in
+	// draft-irtf-cfrg-hash-to-curve-05, which differs in the choice of the
+	// sign of the y-coordinate.
+	//
+	// Deprecated: only kept to reproduce outputs of draft-05 suites; use ELL2.
+	ELL2Draft05
+	// EDELL2Draft05 is Elligator2 method for twisted Edwards curves as
+	// specified in draft-irtf-cfrg-hash-to-curve-05.
+	//
+	// Deprecated: only kept to reproduce outputs of draft-05 suites; use EDELL2.
+	EDELL2Draft05
 )
 
 // Get returns a MapToCurve implementation based on ID provided. Some arguments
-// can be set to nil if there are not required by the mapping.
+// can be set to nil if there are not required by the mapping. It panics if
+// the mapping cannot be instantiated, see TryGet.
 func (id ID) Get(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID, iso func() C.Isogeny) MapToCurve {
+	return mustMap(id.TryGet(e, z, sgn0, iso))
+}
+
+// TryGet is like Get but it returns an error if the mapping is not supported,
+// or the curve or Z do not satisfy the conditions required by the mapping.
+func (id ID) TryGet(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID, iso func() C.Isogeny) (MapToCurve, error) {
 	switch id {
 	case BF:
-		return NewBF(e)
+		return NewBFE(e)
 	case SSWU:
-		return NewSSWU(e, z, sgn0, iso)
+		return NewSSWUE(e, z, sgn0, iso)
 	case SVDW:
-		return NewSVDW(e, sgn0)
+		return NewSVDWE(e, sgn0)
 	case ELL2, EDELL2:
-		return NewElligator2(e, sgn0)
+		return NewElligator2E(e, sgn0)
+	case ELL2Draft05, EDELL2Draft05:
+		return newElligator2(e, sgn0, true)
 	default:
-		panic("Mapping not supported")
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, int(id))
+	}
+}
+
+func mustMap(m MapToCurve, err error) MapToCurve {
+	if err != nil {
+		panic(err)
 	}
+	return m
 }
@@ -1,6 +1,8 @@
 package mapping_test
 
 import (
+	"crypto/rand"
+	"errors"
 	"testing"
 
 	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
@@ -99,8 +101,177 @@ func TestSSWU(t *testing.T) {
 	}
 }
 
+func TestSSWUReference(t *testing.T) {
+	var curves = []struct {
+		Name string
+		Z    int
+	}{
+		{"W0", 3},
+		{"W1iso", 3},
+		{"W4", 2},
+	}
+	for _, c := range curves {
+		E := toy.ToyCurves[c.Name].E
+		F := E.Field()
+		n := F.Order().Int64()
+		Z := F.Elt(c.Z)
+		for _, s := range []GF.Sgn0ID{GF.SignLE, GF.SignBE} {
+			got := mapping.NewSSWU(E, Z, s, nil)
+			want := mapping.NewSSWUReference(E, Z, s)
+			for i := int64(0); i < n; i++ {
+				u := F.Elt(i)
+				P, Q := got.Map(u), want.Map(u)
+				if !P.IsEqual(Q) {
+					t.Fatalf("%v u: %v\ngot:  %v\nwant: %v\n", c.Name, u, P, Q)
+				}
+			}
+		}
+	}
+}
+
+func TestInverse(t *testing.T) {
+	// Exceptional inputs of SSWU and SVDW are not recovered, there are at
+	// most three for SSWU and four for SVDW.
+	var curves = []struct {
+		Name        string
+		Map         mapping.ID
+		Z           int
+		Unrecovered int
+	}{
+		{"M0", mapping.ELL2, 0, 0},
+		{"M1", mapping.ELL2, 0, 0},
+		{"E0", mapping.EDELL2, 0, 0},
+		{"E1", mapping.EDELL2, 0, 0},
+		{"W0", mapping.SSWU, 3, 3},
+		{"W1iso", mapping.SSWU, 3, 3},
+		{"W4", mapping.SSWU, 2, 3},
+		{"W0", mapping.SVDW, 0, 4},
+		{"W1", mapping.SVDW, 0, 4},
+		{"W4", mapping.SVDW, 0, 4},
+	}
+	for _, c := range curves {
+		E := toy.ToyCurves[c.Name].E
+		F := E.Field()
+		n := F.Order().Int64()
+		for _, s := range []GF.Sgn0ID{GF.SignLE, GF.SignBE} {
+			m := c.Map.Get(E, F.Elt(c.Z), s, nil).(mapping.InvertibleMap)
+			unrecovered := 0
+			for i := int64(0); i < n; i++ {
+				u := F.Elt(i)
+				P := m.Map(u)
+				found := false
+				for b := 0; b < 4; b++ {
+					if v, ok := m.Inverse(P, b); ok {
+						if !m.Map(v).IsEqual(P) {
+							t.Fatalf("%v u: %v branch: %v got: %v\n", c.Name, u, b, v)
+						}
+						found = found || F.AreEqual(u, v)
+					}
+				}
+				if !found {
+					unrecovered++
+				}
+			}
+			if unrecovered > c.Unrecovered {
+				t.Fatalf("%v %v: %v inputs not recovered\n", c.Name, m, unrecovered)
+			}
+		}
+	}
+}
+
+func TestElligatorSquared(t *testing.T) {
+	var curves = []struct {
+		E   C.CurveID
+		Map mapping.ID
+		Z   interface{}
+	}{
+		{C.P256, mapping.SSWU, -10},
+		{C.P384, mapping.SSWU, -12},
+		{C.P521, mapping.SSWU, -4},
+		{C.P256, mapping.SVDW, nil},
+		{C.P384, mapping.SVDW, nil},
+		{C.P521, mapping.SVDW, nil},
+		{C.SECP256K1, mapping.SVDW, nil},
+		{C.BLS12381G1, mapping.SVDW, nil},
+		{C.BLS12381G2, mapping.SVDW, nil},
+	}
+	for _, c := range curves {
+		E := c.E.Get()
+		F := E.Field()
+		var Z GF.Elt
+		if c.Z != nil {
+			Z = F.Elt(c.Z)
+		}
+		m := c.Map.Get(E, Z, GF.SignLE, nil)
+		s := mapping.NewElligatorSquared(E, m, 128)
+		for i := 0; i < 4; i++ {
+			P := E.Add(m.Map(F.Rand(rand.Reader)), m.Map(F.Rand(rand.Reader)))
+			b := s.Encode(P, rand.Reader)
+			if len(b) != s.Size() {
+				t.Fatalf("%v: wrong size got: %v want: %v\n", c.E, len(b), s.Size())
+			}
+			if Q := s.Decode(b); !Q.IsEqual(P) {
+				t.Fatalf("%v: got: %v want: %v\n", c.E, Q, P)
+			}
+		}
+	}
+
+	// SSWU through an isogeny has no inverse map.
+	for _, c := range []struct {
+		E   C.CurveID
+		Z   interface{}
+		Iso func() C.Isogeny
+	}{
+		{C.SECP256K1, -11, C.GetSECP256K1Isogeny},
+		{C.BLS12381G1, 11, C.GetBLS12381G1Isogeny},
+		{C.BLS12381G2, []interface{}{-2, -1}, C.GetBLS12381G2Isogeny},
+	} {
+		E := c.E.Get()
+		m := mapping.NewSSWU(E, E.Field().Elt(c.Z), GF.SignLE, c.Iso)
+		if _, err := mapping.NewElligatorSquaredE(E, m, 128); !errors.Is(err, mapping.ErrNotInvertible) {
+			t.Fatalf("%v: expected an error on SSWU with isogeny: %v", c.E, err)
+		}
+	}
+}
+
+// noInverse is an InvertibleMap that finds no preimages.
+type noInverse struct{ mapping.MapToCurve }
+
+func (noInverse) Inverse(C.Point, int) (GF.Elt, bool) { return nil, false }
+
 type doubleIso struct{ E C.EllCurve }
 
 func (d doubleIso) Domain() C.EllCurve     { return d.E }
 func (d doubleIso) Codomain() C.EllCurve   { return d.E }
 func (d doubleIso) Push(p C.Point) C.Point { return d.E.Double(p) }
+
+func TestErrors(t *testing.T) {
+	P256 := C.P256.Get()
+	F := P256.Field()
+	_, errID := mapping.ID(255).TryGet(P256, nil, GF.SignLE, nil)
+	_, errCurve := mapping.NewSSWUE(C.Curve25519.Get(), nil, GF.SignLE, nil)
+	_, errZ := mapping.NewSSWUE(P256, F.One(), GF.SignLE, nil)
+	_, errEll2 := mapping.NewElligator2E(P256, GF.SignLE)
+	_, errInv := mapping.NewElligatorSquaredE(P256, mapping.NewBF(toy.ToyCurves["W2"].E), 128)
+	es := mapping.NewElligatorSquared(P256, mapping.NewSSWU(P256, F.Elt(-10), GF.SignLE, nil), 128)
+	_, errDecode := es.DecodeE(make([]byte, es.Size()-1))
+	ni := mapping.NewElligatorSquared(P256, noInverse{mapping.NewSSWU(P256, F.Elt(-10), GF.SignLE, nil)}, 128)
+	_, errEncode := ni.EncodeE(P256.Identity(), rand.Reader)
+	for _, v := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"TryGet", errID, mapping.ErrUnsupported},
+		{"NewSSWUE", errCurve, mapping.ErrInvalidCurve},
+		{"NewSSWUE", errZ, mapping.ErrInvalidZ},
+		{"NewElligator2E", errEll2, mapping.ErrInvalidCurve},
+		{"NewElligatorSquaredE", errInv, mapping.ErrNotInvertible},
+		{"DecodeE", errDecode, mapping.ErrInvalidEncoding},
+		{"EncodeE", errEncode, mapping.ErrNoEncoding},
+	} {
+		if !errors.Is(v.err, v.want) {
+			t.Fatalf("%v: got: %v want: %v", v.name, v.err, v.want)
+		}
+	}
+}
@@ -15,9 +15,18 @@ type mtEll2 struct {
 
 func (m mtEll2) String() string { return fmt.Sprintf("Montgomery Elligator2 for E: %v", m.E) }
 
-func newMTEll2(e C.M, sgn0 GF.Sgn0ID) MapToCurve {
+func newMTEll2(e C.M, sgn0 GF.Sgn0ID, draft05 bool) (MapToCurve, error) {
 	rat := e.ToWeierstrassC()
-	return &mtEll2{e, rat, newWCEll2(rat.Codomain().(C.WC), sgn0)}
+	m, err := newWCEll2(rat.Codomain().(C.WC), sgn0, draft05)
+	if err != nil {
+		return nil, err
+	}
+	return &mtEll2{e, rat, m}, nil
 }
 
 func (m *mtEll2) Map(u GF.Elt) C.Point { return m.Pull(m.MapToCurve.Map(u)) }
+
+// Inverse returns a preimage of P, see InvertibleMap.
+func (m *mtEll2) Inverse(p C.Point, branch int) (GF.Elt, bool) {
+	return m.MapToCurve.(InvertibleMap).Inverse(m.Push(p), branch)
+}
@@ -9,16 +9,36 @@ import (
 
 // NewSSWU implements the Simplified SWU method. If a non-nil isogeny (e0 -> e)
 // is provided, it first maps points to e0 and then applies the isogeny to get
-// a point on e.
+// a point on e. It panics if the curve or Z are not supported, see NewSSWUE.
+//
+// The map is an InvertibleMap only if no isogeny is applied. Inverting the
+// isogeny requires finding roots of polynomials of degree up to 11, and the
+// isogeny may not be injective on rational points when the order of the curve
+// is a multiple of its degree, as for BLS12381G1. Hence, SSWU on SECP256K1,
+// BLS12381G1 and BLS12381G2 cannot be used with ElligatorSquared; use SVDW
+// instead.
 func NewSSWU(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID, iso func() C.Isogeny) MapToCurve {
-	E := e.(C.W)
+	return mustMap(NewSSWUE(e, z, sgn0, iso))
+}
+
+// NewSSWUE is like NewSSWU but it returns ErrInvalidCurve or ErrInvalidZ if
+// the curve or Z do not satisfy the conditions of the mapping.
+func NewSSWUE(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID, iso func() C.Isogeny) (MapToCurve, error) {
+	E, ok := e.(C.W)
+	if !ok {
+		return nil, ErrInvalidCurve
+	}
 	F := E.F
 	cond1 := F.IsZero(E.A)
 	cond2 := F.IsZero(E.B)
 	cond3 := iso != nil
 	if (cond1 || cond2) && cond3 {
 		isogeny := iso()
-		return &sswuAB0{E, isogeny, newSSWU(isogeny.Domain(), z, sgn0)}
+		m, err := newSSWU(isogeny.Domain(), z, sgn0)
+		if err != nil {
+			return nil, err
+		}
+		return &sswuAB0{E, isogeny, m}, nil
 	}
 	return newSSWU(e, z, sgn0)
 }
@@ -28,16 +48,22 @@ type sswu struct {
 	Z      GF.Elt
 	c1, c2 GF.Elt
 	Sgn0   func(GF.Elt) int
+	sqrt   GF.SqrtRatio // sqrt_ratio for Z
 }
 
 func (m sswu) String() string { return fmt.Sprintf("Simple SWU for E: %v", m.E) }
 
-func newSSWU(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID) MapToCurve {
-	if s := (&sswu{E: e.(C.W), Z: z}); s.verify() {
-		s.precmp(sgn0)
-		return s
+func newSSWU(e C.EllCurve, z GF.Elt, sgn0 GF.Sgn0ID) (MapToCurve, error) {
+	E, ok := e.(C.W)
+	if !ok {
+		return nil, ErrInvalidCurve
 	}
-	panic(fmt.Errorf("Failed restrictions for sswu"))
+	s := &sswu{E: E, Z: z}
+	if err := s.verify(); err != nil {
+		return nil, err
+	}
+	s.precmp(sgn0)
+	return s, nil
 }
 
 func (m *sswu) precmp(sgn0 GF.Sgn0ID) {
@@ -49,12 +75,19 @@ func (m *sswu) precmp(sgn0 GF.Sgn0ID) {
 	m.c1 = F.Neg(t0)      // -B/A
 	t0 = F.Inv(m.Z)       // 1/Z
 	m.c2 = F.Neg(t0)      // -1/Z
+	m.sqrt = GF.NewSqrtRatio(F, m.Z)
 }
 
-func (m *sswu) verify() bool {
+func (m *sswu) verify() error {
 	F := m.E.F
-	precond1 := !F.IsZero(m.E.A)         // A != 0
-	precond2 := !F.IsZero(m.E.B)         // B != 0
+	precond1 := !F.IsZero(m.E.A) // A != 0
+	precond2 := !F.IsZero(m.E.B) // B != 0
+	if !precond1 || !precond2 {
+		return ErrInvalidCurve
+	}
+	if m.Z == nil {
+		return ErrInvalidZ
+	}
 	cond1 := !F.IsSquare(m.Z)            // Z is non-square
 	cond2 := !F.AreEqual(m.Z, F.Elt(-1)) // Z != -1
 	t0 := F.Mul(m.Z, m.E.A)              // Z*A
@@ -62,10 +95,94 @@ func (m *sswu) verify() bool {
 	t0 = F.Mul(t0, m.E.B)                // B/(Z*A)
 	g := m.E.EvalRHS(t0)                 // g(B/(Z*A))
 	cond4 := F.IsSquare(g)               // g(B/(Z*A)) is square
-	return precond1 && precond2 && cond1 && cond2 && cond4
+	if !cond1 || !cond2 || !cond4 {
+		return fmt.Errorf("%w: %v", ErrInvalidZ, m.Z)
+	}
+	return nil
 }
 
+// Map is the straight-line implementation of the Simplified SWU method, which
+// uses a single exponentiation given by sqrt_ratio (RFC 9380, Appendix F.2).
 func (m *sswu) Map(u GF.Elt) C.Point {
+	F := m.E.F
+	A, B := m.E.A, m.E.B
+	var tv1, tv2, tv3, tv4, tv5, tv6, x, y, y1 GF.Elt
+	var isGx1Square, e1 bool
+
+	tv1 = F.Sqr(u)                                // 1.  tv1 = u^2
+	tv1 = F.Mul(m.Z, tv1)                         // 2.  tv1 = Z * tv1
+	tv2 = F.Sqr(tv1)                              // 3.  tv2 = tv1^2
+	tv2 = F.Add(tv2, tv1)                         // 4.  tv2 = tv2 + tv1
+	tv3 = F.Add(tv2, F.One())                     // 5.  tv3 = tv2 + 1
+	tv3 = F.Mul(B, tv3)                           // 6.  tv3 = B * tv3
+	tv4 = F.CMov(m.Z, F.Neg(tv2), !F.IsZero(tv2)) // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
+	tv4 = F.Mul(A, tv4)                           // 8.  tv4 = A * tv4
+	tv2 = F.Sqr(tv3)                              // 9.  tv2 = tv3^2
+	tv6 = F.Sqr(tv4)                              // 10. tv6 = tv4^2
+	tv5 = F.Mul(A, tv6)                           // 11. tv5 = A * tv6
+	tv2 = F.Add(tv2, tv5)                         // 12. tv2 = tv2 + tv5
+	tv2 = F.Mul(tv2, tv3)                         // 13. tv2 = tv2 * tv3
+	tv6 = F.Mul(tv6, tv4)                         // 14. tv6 = tv6 * tv4
+	tv5 = F.Mul(B, tv6)                           // 15. tv5 = B * tv6
+	tv2 = F.Add(tv2, tv5)                         // 16. tv2 = tv2 + tv5
+	x = F.Mul(tv1, tv3)                           // 17.   x = tv1 * tv3
+	isGx1Square, y1 = m.sqrt.SqrtRatio(tv2, tv6)  // 18. (is_gx1_square, y1) = sqrt_ratio(tv2, tv6)
+	y = F.Mul(tv1, u)                             // 19.   y = tv1 * u
+	y = F.Mul(y, y1)                              // 20.   y = y * y1
+	x = F.CMov(x, tv3, isGx1Square)               // 21.   x = CMOV(x, tv3, is_gx1_square)
+	y = F.CMov(y, y1, isGx1Square)                // 22.   y = CMOV(y, y1, is_gx1_square)
+	e1 = m.Sgn0(u) == m.Sgn0(y)                   // 23.  e1 = sgn0(u) == sgn0(y)
+	y = F.CMov(F.Neg(y), y, e1)                   // 24.   y = CMOV(-y, y, e1)
+	tv4 = F.Inv0(tv4)                             // 25. tv4 = inv0(tv4)
+	x = F.Mul(x, tv4)                             // 26.   x = x * tv4
+	return m.E.NewPoint(x, y)
+}
+
+// Inverse returns a preimage of P. Let t = Z*u^2, then x is either
+// x1 = c1*(1 + 1/(t^2 + t)) or x2 = t*x1; so t is a root of a quadratic
+// polynomial. The second bit of branch selects whether x is x1 or x2, and the
+// first bit selects the root; the sign of u is determined by the sign of y.
+// Inputs in the exceptional case t^2 + t = 0 are not recovered.
+func (m *sswu) Inverse(p C.Point, branch int) (GF.Elt, bool) {
+	F := m.E.F
+	if p.IsIdentity() {
+		return F.Zero(), false
+	}
+	var k, b, c, d, s, t, u GF.Elt
+	half := F.Inv(F.Elt(2))
+	k = F.Mul(p.X(), F.Inv(m.c1)) // k = x/c1
+	if branch&2 == 0 {
+		// x = x1, so t^2 + t - 1/(k - 1) = 0.
+		if F.AreEqual(k, F.One()) {
+			return F.Zero(), false
+		}
+		b = F.One()                         // b = 1
+		c = F.Neg(F.Inv(F.Sub(k, F.One()))) // c = -1/(k - 1)
+	} else {
+		// x = x2, so s = t + 1 satisfies s^2 - (k + 1)s + 1 = 0.
+		b = F.Neg(F.Add(k, F.One())) // b = -(k + 1)
+		c = F.One()                  // c = 1
+	}
+	d = F.Sub(F.Sqr(b), F.Add(F.Add(c, c), F.Add(c, c))) // d = b^2 - 4c
+	if !F.IsSquare(d) {
+		return F.Zero(), false
+	}
+	d = F.Sqrt(d)
+	d = F.CMov(d, F.Neg(d), branch&1 != 0)
+	s = F.Mul(F.Sub(d, b), half) // s = (-b +/- sqrt(d))/2
+	t = F.CMov(s, F.Sub(s, F.One()), branch&2 != 0)
+	t = F.Mul(t, F.Inv(m.Z)) // u^2 = t/Z
+	if !F.IsSquare(t) {
+		return F.Zero(), false
+	}
+	u = F.Sqrt(t)
+	u = F.CMov(F.Neg(u), u, m.Sgn0(u) == m.Sgn0(p.Y()))
+	return u, m.Map(u).IsEqual(p)
+}
+
+// mapReference is the implementation of the Simplified SWU method that
+// computes inv0, is_square and sqrt separately (RFC 9380, Section 6.6.2).
+func (m *sswu) mapReference(u GF.Elt) C.Point {
 	F := m.E.F
 	var t1, t2 GF.Elt
 	var x1, x2, gx1, gx2, y2, x, y GF.Elt
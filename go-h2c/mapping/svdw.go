@@ -16,12 +16,20 @@ type svdw struct {
 
 func (m svdw) String() string { return fmt.Sprintf("SVDW for E: %v", m.E) }
 
-// NewSVDW implements the Shallue-van de Woestijne method.
-func NewSVDW(e C.EllCurve, sgn0 GF.Sgn0ID) MapToCurve {
-	curve := e.(C.W)
+// NewSVDW implements the Shallue-van de Woestijne method, it panics if the
+// curve is not supported, see NewSVDWE.
+func NewSVDW(e C.EllCurve, sgn0 GF.Sgn0ID) MapToCurve { return mustMap(NewSVDWE(e, sgn0)) }
+
+// NewSVDWE implements the Shallue-van de Woestijne method, or returns
+// ErrInvalidCurve if the curve is not in Weierstrass form.
+func NewSVDWE(e C.EllCurve, sgn0 GF.Sgn0ID) (MapToCurve, error) {
+	curve, ok := e.(C.W)
+	if !ok {
+		return nil, ErrInvalidCurve
+	}
 	s := &svdw{E: curve, Sgn0: curve.F.GetSgn0(sgn0)}
 	s.precmp()
-	return s
+	return s, nil
 }
 
 func (m *svdw) findZ() {
@@ -133,3 +141,58 @@ func (m *svdw) Map(u GF.Elt) C.Point {
 
 	return m.E.NewPoint(x, y)
 }
+
+// Inverse returns a preimage of P. If the second bit of branch is set, it
+// assumes that x was computed as x3; otherwise, as either x1 or x2, which are
+// related by negating u. The first bit of branch selects the root of the
+// equation solved for u, and the sign of u is determined by the sign of y.
+// Inputs in the exceptional case c1*u^2 = 1 or c1*u^2 = -1 are not recovered.
+func (m *svdw) Inverse(p C.Point, branch int) (GF.Elt, bool) {
+	F := m.E.F
+	if p.IsIdentity() {
+		return F.Zero(), false
+	}
+	var w, d, r, v, u GF.Elt
+	x := p.X()
+	if branch&2 == 0 {
+		// x = c2 - w, where w = c3*u/(1 + c1*u^2), so u is a root of
+		// c1*w*u^2 - c3*u + w = 0.
+		w = F.Sub(m.c2, x)
+		if F.IsZero(w) {
+			u = F.Zero()
+			return u, branch&1 == 0 && m.Map(u).IsEqual(p)
+		}
+		d = F.Mul(F.Sqr(w), m.c1)                               // c1*w^2
+		d = F.Sub(F.Sqr(m.c3), F.Add(F.Add(d, d), F.Add(d, d))) // c3^2 - 4*c1*w^2
+		if !F.IsSquare(d) {
+			return F.Zero(), false
+		}
+		r = F.Sqrt(d)
+		r = F.CMov(r, F.Neg(r), branch&1 != 0)
+		w = F.Mul(m.c1, w)     // c1*w
+		w = F.Add(w, w)        // 2*c1*w
+		u = F.Add(m.c3, r)     // c3 +/- sqrt(d)
+		u = F.Mul(u, F.Inv(w)) // u = (c3 +/- sqrt(d))/(2*c1*w)
+	} else {
+		// x = Z + c4*r^2, where r = (1 + c1*u^2)/(1 - c1*u^2).
+		r = F.Sub(x, m.Z)
+		r = F.Mul(r, F.Inv(m.c4)) // r^2 = (x - Z)/c4
+		if !F.IsSquare(r) {
+			return F.Zero(), false
+		}
+		r = F.Sqrt(r)
+		r = F.CMov(r, F.Neg(r), branch&1 != 0)
+		v = F.Add(r, F.One()) // r + 1
+		if F.IsZero(v) {
+			return F.Zero(), false
+		}
+		v = F.Mul(F.Sub(r, F.One()), F.Inv(v)) // c1*u^2 = (r - 1)/(r + 1)
+		v = F.Mul(v, F.Inv(m.c1))              // u^2
+		if !F.IsSquare(v) {
+			return F.Zero(), false
+		}
+		u = F.Sqrt(v)
+	}
+	u = F.CMov(F.Neg(u), u, m.Sgn0(u) == m.Sgn0(p.Y()))
+	return u, m.Map(u).IsEqual(p)
+}
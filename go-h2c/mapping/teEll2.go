@@ -15,21 +15,31 @@ type teEll2 struct {
 
 func (m teEll2) String() string { return fmt.Sprintf("Edwards Elligator2 for E: %v", m.E) }
 
-func newTEEll2(e C.T, sgn0 GF.Sgn0ID) MapToCurve {
+func newTEEll2(e C.T, sgn0 GF.Sgn0ID, draft05 bool) (MapToCurve, error) {
 	var rat C.RationalMap
 	var ell2Map MapToCurve
+	var err error
 	switch e.Id {
-	case C.Edwards25519:
-		rat = C.FromTe2Mt25519()
-		ell2Map = newMTEll2(rat.Codomain().(C.M), sgn0)
-	case C.Edwards448:
-		rat = C.FromTe2Mt4ISO448()
-		ell2Map = newMTEll2(rat.Codomain().(C.M), sgn0)
+	case C.Edwards25519, C.Edwards448:
+		if rat, err = C.FromTe2Mt(e); err != nil {
+			return nil, err
+		}
+		ell2Map, err = newMTEll2(rat.Codomain().(C.M), sgn0, draft05)
 	default:
 		rat = e.ToWeierstrassC()
-		ell2Map = newWCEll2(rat.Codomain().(C.WC), sgn0)
+		ell2Map, err = newWCEll2(rat.Codomain().(C.WC), sgn0, draft05)
 	}
-	return &teEll2{e, rat, ell2Map}
+	if err != nil {
+		return nil, err
+	}
+	return &teEll2{e, rat, ell2Map}, nil
 }
 
 func (m *teEll2) Map(u GF.Elt) C.Point { return m.Pull(m.MapToCurve.Map(u)) }
+
+// Inverse returns a preimage of P, see InvertibleMap. For edwards448, the map
+// is composed with a 4-isogeny, so only points in its image have a preimage.
+func (m *teEll2) Inverse(p C.Point, branch int) (GF.Elt, bool) {
+	u, ok := m.MapToCurve.(InvertibleMap).Inverse(m.Push(p), branch)
+	return u, ok && m.Map(u).IsEqual(p)
+}
@@ -8,19 +8,22 @@ import (
 )
 
 type wcEll2 struct {
-	E    C.WC
-	Z    GF.Elt
-	Sgn0 func(GF.Elt) int
+	E       C.WC
+	Z       GF.Elt
+	Sgn0    func(GF.Elt) int
+	draft05 bool
+	sqrt    GF.SqrtRatio // sqrt_ratio for Z
 }
 
 func (m wcEll2) String() string { return fmt.Sprintf("Elligator2 for E: %v", m.E) }
 
-func newWCEll2(e C.WC, sgn0 GF.Sgn0ID) MapToCurve {
+func newWCEll2(e C.WC, sgn0 GF.Sgn0ID, draft05 bool) (MapToCurve, error) {
 	F := e.F
 	if !F.IsZero(e.A) && !F.IsZero(e.B) { // A != 0 and  B != 0
-		return &wcEll2{e, findZ(F), F.GetSgn0(sgn0)}
+		Z := findZ(F)
+		return &wcEll2{e, Z, F.GetSgn0(sgn0), draft05, GF.NewSqrtRatio(F, Z)}, nil
 	}
-	panic("Curve didn't match elligator2 mapping")
+	return nil, ErrInvalidCurve
 }
 
 func findZ(f GF.Field) GF.Elt {
@@ -40,24 +43,50 @@ func (m *wcEll2) Map(u GF.Elt) C.Point {
 	var t1 GF.Elt
 	var x1, x2, gx1, gx2, y2, x, y GF.Elt
 	var e1, e2, e3 bool
-	t1 = F.Sqr(u)                  // 1.   t1 = u^2
-	t1 = F.Mul(m.Z, t1)            // 2.   t1 = Z * t1              // Z * u^2
-	e1 = F.AreEqual(t1, F.Elt(-1)) // 3.   e1 = t1 == -1            // exceptional case: Z * u^2 == -1
-	t1 = F.CMov(t1, F.Zero(), e1)  // 4.   t1 = CMOV(t1, 0, e1)     // if t1 == -1, set t1 = 0
-	x1 = F.Add(t1, F.One())        // 5.   x1 = t1 + 1
-	x1 = F.Inv0(x1)                // 6.   x1 = inv0(x1)
-	x1 = F.Mul(F.Neg(m.E.A), x1)   // 7.   x1 = -A * x1             // x1 = -A / (1 + Z * u^2)
-	gx1 = F.Add(x1, m.E.A)         // 8.  gx1 = x1 + A
-	gx1 = F.Mul(gx1, x1)           // 9.  gx1 = gx1 * x1
-	gx1 = F.Add(gx1, m.E.B)        // 10. gx1 = gx1 + B
-	gx1 = F.Mul(gx1, x1)           // 11. gx1 = gx1 * x1            // gx1 = x1^3 + A * x1^2 + B * x1
-	x2 = F.Sub(F.Neg(x1), m.E.A)   // 12.  x2 = -x1 - A
-	gx2 = F.Mul(t1, gx1)           // 13. gx2 = t1 * gx1
-	e2 = F.IsSquare(gx1)           // 14.  e2 = is_square(gx1)
-	x = F.CMov(x2, x1, e2)         // 15.   x = CMOV(x2, x1, e2)    // If is_square(gx1), x = x1, else x = x2
-	y2 = F.CMov(gx2, gx1, e2)      // 16.  y2 = CMOV(gx2, gx1, e2)  // If is_square(gx1), y2 = gx1, else y2 = gx2
-	y = F.Sqrt(y2)                 // 17.   y = sqrt(y2)
-	e3 = m.Sgn0(u) == m.Sgn0(y)    // 18.  e3 = sgn0(u) == sgn0(y)  // Fix sign of y
-	y = F.CMov(F.Neg(y), y, e3)    // 19.   y = CMOV(-y, y, e3)
+	t1 = F.Sqr(u)                     // 1.   t1 = u^2
+	t1 = F.Mul(m.Z, t1)               // 2.   t1 = Z * t1              // Z * u^2
+	e1 = F.AreEqual(t1, F.Elt(-1))    // 3.   e1 = t1 == -1            // exceptional case: Z * u^2 == -1
+	t1 = F.CMov(t1, F.Zero(), e1)     // 4.   t1 = CMOV(t1, 0, e1)     // if t1 == -1, set t1 = 0
+	x1 = F.Add(t1, F.One())           // 5.   x1 = t1 + 1
+	x1 = F.Inv0(x1)                   // 6.   x1 = inv0(x1)
+	x1 = F.Mul(F.Neg(m.E.A), x1)      // 7.   x1 = -A * x1             // x1 = -A / (1 + Z * u^2)
+	gx1 = F.Add(x1, m.E.A)            // 8.  gx1 = x1 + A
+	gx1 = F.Mul(gx1, x1)              // 9.  gx1 = gx1 * x1
+	gx1 = F.Add(gx1, m.E.B)           // 10. gx1 = gx1 + B
+	gx1 = F.Mul(gx1, x1)              // 11. gx1 = gx1 * x1            // gx1 = x1^3 + A * x1^2 + B * x1
+	x2 = F.Sub(F.Neg(x1), m.E.A)      // 12.  x2 = -x1 - A
+	gx2 = F.Mul(t1, gx1)              // 13. gx2 = t1 * gx1
+	e2 = F.IsSquare(gx1)              // 14.  e2 = is_square(gx1)
+	x = F.CMov(x2, x1, e2)            // 15.   x = CMOV(x2, x1, e2)    // If is_square(gx1), x = x1, else x = x2
+	y2 = F.CMov(gx2, gx1, e2)         // 16.  y2 = CMOV(gx2, gx1, e2)  // If is_square(gx1), y2 = gx1, else y2 = gx2
+	y = F.Sqrt(y2)                    // 17.   y = sqrt(y2)
+	e3 = m.Sgn0(y) == -1              // 18.  e3 = sgn0(y) == 1       // Fix sign of y
+	y = F.CMov(y, F.Neg(y), e2 != e3) // 19.   y = CMOV(y, -y, e2 XOR e3)
+	if m.draft05 {
+		// draft-05 instead sets y such that sgn0(u) == sgn0(y).
+		y = F.CMov(F.Neg(y), y, m.Sgn0(u) == m.Sgn0(y))
+	}
 	return m.E.NewPoint(x, y)
 }
+
+// Inverse returns a preimage of P. If the second bit of branch is set, it
+// assumes that x was computed as x2 = -x1 - A instead of x1, and the first
+// bit of branch selects the sign of the preimage.
+func (m *wcEll2) Inverse(p C.Point, branch int) (GF.Elt, bool) {
+	F := m.E.F
+	if p.IsIdentity() {
+		return F.Zero(), false
+	}
+	var x1, t0, t1, u GF.Elt
+	var isQR bool
+	x := p.X()
+	x1 = F.CMov(x, F.Sub(F.Neg(x), m.E.A), branch&2 != 0) // x1 = x or x1 = -x - A
+	if F.IsZero(x1) {
+		return F.Zero(), false
+	}
+	t0 = F.Neg(F.Add(x1, m.E.A))       // -(x1 + A)
+	t1 = F.Mul(m.Z, x1)                // Z * x1
+	isQR, u = m.sqrt.SqrtRatio(t0, t1) // u = sqrt(-(x1 + A) / (Z * x1))
+	u = F.CMov(u, F.Neg(u), branch&1 != 0)
+	return u, isQR && m.Map(u).IsEqual(p)
+}
@@ -1,13 +1,21 @@
 package h2c_test
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	h2c "github.com/armfazh/hash-to-curve-ref/go-h2c"
+	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
+	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
 )
 
 type vectorSuite struct {
@@ -29,22 +37,33 @@ type vectorSuite struct {
 			X string `json:"x"`
 			Y string `json:"y"`
 		} `json:"P"`
-		Msg string `json:"msg"`
+		Msg string   `json:"msg"`
+		U   []string `json:"u"`
 	} `json:"vectors"`
 }
 
-func (v vectorSuite) test(t *testing.T) {
-	hashToCurve, err := h2c.SuiteID(v.SuiteID).Get()
+func (v vectorSuite) test(t *testing.T, b GF.Backend) {
+	hashToCurve, err := h2c.SuiteID(v.SuiteID).GetBackend(b)
 	if err != nil {
 		t.Skipf(err.Error())
 	}
 	E := hashToCurve.GetCurve()
 	F := E.Field()
 	for i := range v.Vectors {
+		if len(v.Vectors[i].U) > 0 {
+			u := hashToCurve.HashToField([]byte(v.Vectors[i].Msg), []byte(v.DST), len(v.Vectors[i].U))
+			for j := range u {
+				got := u[j]
+				want := eltFromString(F, v.Vectors[i].U[j])
+				if !F.AreEqual(got, want) {
+					t.Fatalf("suite: %v\ngot:  %v\nwant: %v", v.SuiteID, got, want)
+				}
+			}
+		}
 		got := hashToCurve.Hash([]byte(v.Vectors[i].Msg), []byte(v.DST))
 		want := E.NewPoint(
-			F.Elt(v.Vectors[i].P.X),
-			F.Elt(v.Vectors[i].P.Y),
+			eltFromString(F, v.Vectors[i].P.X),
+			eltFromString(F, v.Vectors[i].P.Y),
 		)
 		if !got.IsEqual(want) {
 			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", v.SuiteID, got, want)
@@ -52,13 +71,33 @@ func (v vectorSuite) test(t *testing.T) {
 	}
 }
 
-func TestVectors(t *testing.T) {
+// eltFromString parses a field element whose coordinates are separated by commas.
+func eltFromString(F GF.Field, s string) GF.Elt {
+	coords := strings.Split(s, ",")
+	v := make([]interface{}, len(coords))
+	for i := range coords {
+		v[i] = coords[i]
+	}
+	return F.Elt(v)
+}
+
+func TestVectors(t *testing.T) { testVectors(t, GF.BigInt) }
+
+func TestVectorsBackends(t *testing.T) {
+	t.Run("ConstantTime", func(t *testing.T) { testVectors(t, GF.ConstantTime) })
+	t.Run("Specialized", func(t *testing.T) { testVectors(t, GF.Specialized) })
+}
+
+func testVectors(t *testing.T, b GF.Backend) {
 	if errFolder := filepath.Walk("testdata",
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 			if info.IsDir() {
+				if path != "testdata" {
+					return filepath.SkipDir
+				}
 				return nil
 			}
 			jsonFile, errFile := os.Open(path)
@@ -76,21 +115,185 @@ func TestVectors(t *testing.T) {
 			if errJSON != nil {
 				return errJSON
 			}
-			t.Run(v.SuiteID, v.test)
+			t.Run(v.SuiteID, func(t *testing.T) { v.test(t, b) })
 			return nil
 		}); errFolder != nil {
 		t.Fatalf("error on reading testdata folder: %v", errFolder)
 	}
 }
 
+// TestVectorFiles checks that every file in testdata is named after the suite
+// of its vectors, so that RFC 9380 vectors never replace draft-05 vectors.
+func TestVectorFiles(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range files {
+		byteValue, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v vectorSuite
+		if err := json.Unmarshal(byteValue, &v); err != nil {
+			t.Fatal(err)
+		}
+		want := strings.ReplaceAll(v.SuiteID, ":", "-") + ".json"
+		if got := filepath.Base(path); got != want {
+			t.Fatalf("file: %v holds vectors of suite: %v", got, v.SuiteID)
+		}
+		id, err := h2c.ParseSuiteID(v.SuiteID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if draft05 := !strings.HasSuffix(v.DST, v.SuiteID); draft05 != id.IsDeprecated() {
+			t.Fatalf("file: %v holds vectors of another draft", path)
+		}
+	}
+}
+
+func TestDST(t *testing.T) {
+	hashToCurve, _ := h2c.P256_XMDSHA256_SSWU_RO_.Get()
+	msg := []byte("abc")
+	longDST := bytes.Repeat([]byte("QUUX-V01-CS02-long-DST-"), 20)
+	H := sha256.New()
+	H.Write([]byte("H2C-OVERSIZE-DST-"))
+	H.Write(longDST)
+	reducedDST := H.Sum(nil)
+
+	got := hashToCurve.Hash(msg, longDST)
+	want := hashToCurve.Hash(msg, reducedDST)
+	if !got.IsEqual(want) {
+		t.Fatalf("oversize DST\ngot:  %v\nwant: %v", got, want)
+	}
+	if _, err := hashToCurve.HashE(msg, nil); !errors.Is(err, h2c.ErrEmptyDST) {
+		t.Fatalf("expected an error on empty DST: %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected a panic on empty DST")
+		}
+	}()
+	hashToCurve.Hash(msg, nil)
+}
+
+func TestXOFSuites(t *testing.T) {
+	// The edwards448 suites map to curve448 and then apply the 4-isogeny
+	// to edwards448, which commutes with cofactor clearing.
+	iso := C.FromTe2Mt4ISO448()
+	dst := []byte("QUUX-V01-CS02-with-edwards448_XOF:SHAKE256_ELL2")
+	for _, ids := range [][2]h2c.SuiteID{
+		{h2c.Curve448_XOFSHAKE256_ELL2_NU_, h2c.Edwards448_XOFSHAKE256_ELL2_NU_},
+		{h2c.Curve448_XOFSHAKE256_ELL2_RO_, h2c.Edwards448_XOFSHAKE256_ELL2_RO_},
+	} {
+		mt, err := ids[0].Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		te, err := ids[1].Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+			P := mt.Hash([]byte(msg), dst)
+			Q := te.Hash([]byte(msg), dst)
+			if !mt.GetCurve().IsOnCurve(P) || !te.GetCurve().IsOnCurve(Q) {
+				t.Fatalf("point not in the curve")
+			}
+			got := iso.Pull(P)
+			want := Q
+			if !got.IsEqual(want) {
+				t.Fatalf("suite: %v\ngot:  %v\nwant: %v", ids[1], got, want)
+			}
+		}
+	}
+}
+
+func TestHashToScalar(t *testing.T) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-HashToScalar")
+	for _, v := range []struct {
+		ID h2c.SuiteID
+		E  C.CurveID
+		L  int
+	}{
+		{h2c.P256_XMDSHA256_SSWU_RO_, C.P256, 48},
+		{h2c.SECP256K1_XMDSHA256_SSWU_NU_, C.SECP256K1, 48},
+		{h2c.BLS12381G1_XMDSHA256_SSWU_RO_, C.BLS12381G1, 48},
+		{h2c.Edwards448_XOFSHAKE256_ELL2_RO_, C.Edwards448, 84},
+	} {
+		hashToCurve, err := v.ID.Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		S := hashToCurve.GetScalarField()
+		if S.P().Cmp(v.E.Get().Order()) != 0 {
+			t.Fatalf("suite: %v wrong scalar field %v", v.ID, S)
+		}
+		var uniform []byte
+		if v.E == C.Edwards448 {
+			uniform, err = h2c.ExpandMessageXOF(h2c.SHAKE256, 224, msg, dst, v.L)
+		} else {
+			uniform, err = h2c.ExpandMessageXMD(crypto.SHA256, msg, dst, v.L)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := hashToCurve.HashToScalar(msg, dst)
+		want := S.Elt(new(big.Int).SetBytes(uniform))
+		if !S.AreEqual(got, want) {
+			t.Fatalf("suite: %v\ngot:  %v\nwant: %v", v.ID, got, want)
+		}
+		if _, err := hashToCurve.HashToScalarE(msg, nil); !errors.Is(err, h2c.ErrEmptyDST) {
+			t.Fatalf("suite: %v expected an error on empty DST: %v", v.ID, err)
+		}
+	}
+}
+
+func TestSuiteID(t *testing.T) {
+	suites := h2c.Suites()
+	for i := range suites {
+		id, err := h2c.ParseSuiteID(suites[i].String())
+		if err != nil || id != suites[i] {
+			t.Fatalf("suite: %v not parsed: %v", suites[i], err)
+		}
+		if id.IsDeprecated() {
+			t.Fatalf("suite: %v must not be deprecated", id)
+		}
+		if i > 0 && suites[i-1] >= suites[i] {
+			t.Fatalf("suites are not sorted")
+		}
+		// Every suite defined in RFC 9380 is tested against its vectors.
+		path := filepath.Join("testdata", strings.ReplaceAll(id.String(), ":", "-")+".json")
+		if _, err := os.Stat(path); id.IsStandard() && err != nil {
+			t.Fatalf("suite: %v has no test vectors: %v", id, err)
+		}
+	}
+	if h2c.P256_XMDSHA256_SVDW_RO_.IsStandard() || h2c.Curve448_XMDSHA512_ELL2_RO_.IsStandard() {
+		t.Fatalf("suite not defined in RFC 9380 reported as standard")
+	}
+
+	id, err := h2c.ParseSuiteID("P256-SHA256-SSWU-RO-")
+	if err != nil || id != h2c.P256_SHA256_SSWU_RO_ || !id.IsDeprecated() {
+		t.Fatalf("deprecated suite: %v not parsed: %v", id, err)
+	}
+	if _, err := h2c.ParseSuiteID("P256_XMD:SHA-256_SSWU_XX_"); !errors.Is(err, h2c.ErrUnsupportedSuite) {
+		t.Fatalf("expected an error on unknown suite: %v", err)
+	}
+	if _, err := h2c.SuiteID("P256_XMD:SHA-256_SSWU_XX_").Get(); !errors.Is(err, h2c.ErrUnsupportedSuite) {
+		t.Fatalf("expected an error on unknown suite: %v", err)
+	}
+}
+
 func BenchmarkSuites(b *testing.B) {
 	msg := make([]byte, 256)
 	dst := make([]byte, 10)
 	for _, suite := range []h2c.SuiteID{
-		h2c.P256_SHA256_SSWU_NU_,
-		h2c.P256_SHA256_SSWU_RO_,
-		h2c.P256_SHA256_SVDW_NU_,
-		h2c.P256_SHA256_SVDW_RO_,
+		h2c.P256_XMDSHA256_SSWU_NU_,
+		h2c.P256_XMDSHA256_SSWU_RO_,
+		h2c.P256_XMDSHA256_SVDW_NU_,
+		h2c.P256_XMDSHA256_SVDW_RO_,
 	} {
 		b.Run(string(suite), func(b *testing.B) {
 			hashToCurve, _ := suite.Get()
@@ -5,6 +5,7 @@ import (
 	_ "crypto/sha256" // To link the sha256 module
 	_ "crypto/sha512" // To link the sha512 module
 	"fmt"
+	"sort"
 
 	C "github.com/armfazh/hash-to-curve-ref/go-h2c/curve"
 	GF "github.com/armfazh/hash-to-curve-ref/go-h2c/field"
@@ -14,6 +15,57 @@ import (
 // SuiteID is the identifier of supported hash to curve suites.
 type SuiteID string
 
+// Suites named as in RFC 9380.
+const (
+	P256_XMDSHA256_SSWU_NU_         SuiteID = "P256_XMD:SHA-256_SSWU_NU_"
+	P256_XMDSHA256_SSWU_RO_         SuiteID = "P256_XMD:SHA-256_SSWU_RO_"
+	P384_XMDSHA384_SSWU_NU_         SuiteID = "P384_XMD:SHA-384_SSWU_NU_"
+	P384_XMDSHA384_SSWU_RO_         SuiteID = "P384_XMD:SHA-384_SSWU_RO_"
+	P521_XMDSHA512_SSWU_NU_         SuiteID = "P521_XMD:SHA-512_SSWU_NU_"
+	P521_XMDSHA512_SSWU_RO_         SuiteID = "P521_XMD:SHA-512_SSWU_RO_"
+	Curve25519_XMDSHA512_ELL2_NU_   SuiteID = "curve25519_XMD:SHA-512_ELL2_NU_"
+	Curve25519_XMDSHA512_ELL2_RO_   SuiteID = "curve25519_XMD:SHA-512_ELL2_RO_"
+	Edwards25519_XMDSHA512_ELL2_NU_ SuiteID = "edwards25519_XMD:SHA-512_ELL2_NU_"
+	Edwards25519_XMDSHA512_ELL2_RO_ SuiteID = "edwards25519_XMD:SHA-512_ELL2_RO_"
+	Curve448_XOFSHAKE256_ELL2_NU_   SuiteID = "curve448_XOF:SHAKE256_ELL2_NU_"
+	Curve448_XOFSHAKE256_ELL2_RO_   SuiteID = "curve448_XOF:SHAKE256_ELL2_RO_"
+	Edwards448_XOFSHAKE256_ELL2_NU_ SuiteID = "edwards448_XOF:SHAKE256_ELL2_NU_"
+	Edwards448_XOFSHAKE256_ELL2_RO_ SuiteID = "edwards448_XOF:SHAKE256_ELL2_RO_"
+	SECP256K1_XMDSHA256_SSWU_NU_    SuiteID = "secp256k1_XMD:SHA-256_SSWU_NU_"
+	SECP256K1_XMDSHA256_SSWU_RO_    SuiteID = "secp256k1_XMD:SHA-256_SSWU_RO_"
+	BLS12381G1_XMDSHA256_SSWU_NU_   SuiteID = "BLS12381G1_XMD:SHA-256_SSWU_NU_"
+	BLS12381G1_XMDSHA256_SSWU_RO_   SuiteID = "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+	BLS12381G2_XMDSHA256_SSWU_NU_   SuiteID = "BLS12381G2_XMD:SHA-256_SSWU_NU_"
+	BLS12381G2_XMDSHA256_SSWU_RO_   SuiteID = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+)
+
+// Suites not defined in RFC 9380. They are named after the RFC 9380 scheme and
+// use the same expanders and maps, but there are no standard test vectors for
+// them, so other implementations may not support them.
+const (
+	P256_XMDSHA256_SVDW_NU_       SuiteID = "P256_XMD:SHA-256_SVDW_NU_"
+	P256_XMDSHA256_SVDW_RO_       SuiteID = "P256_XMD:SHA-256_SVDW_RO_"
+	P384_XMDSHA384_SVDW_NU_       SuiteID = "P384_XMD:SHA-384_SVDW_NU_"
+	P384_XMDSHA384_SVDW_RO_       SuiteID = "P384_XMD:SHA-384_SVDW_RO_"
+	P521_XMDSHA512_SVDW_NU_       SuiteID = "P521_XMD:SHA-512_SVDW_NU_"
+	P521_XMDSHA512_SVDW_RO_       SuiteID = "P521_XMD:SHA-512_SVDW_RO_"
+	Curve448_XMDSHA512_ELL2_NU_   SuiteID = "curve448_XMD:SHA-512_ELL2_NU_"
+	Curve448_XMDSHA512_ELL2_RO_   SuiteID = "curve448_XMD:SHA-512_ELL2_RO_"
+	Edwards448_XMDSHA512_ELL2_NU_ SuiteID = "edwards448_XMD:SHA-512_ELL2_NU_"
+	Edwards448_XMDSHA512_ELL2_RO_ SuiteID = "edwards448_XMD:SHA-512_ELL2_RO_"
+	SECP256K1_XMDSHA256_SVDW_NU_  SuiteID = "secp256k1_XMD:SHA-256_SVDW_NU_"
+	SECP256K1_XMDSHA256_SVDW_RO_  SuiteID = "secp256k1_XMD:SHA-256_SVDW_RO_"
+	BLS12381G1_XMDSHA256_SVDW_NU_ SuiteID = "BLS12381G1_XMD:SHA-256_SVDW_NU_"
+	BLS12381G1_XMDSHA256_SVDW_RO_ SuiteID = "BLS12381G1_XMD:SHA-256_SVDW_RO_"
+	BLS12381G2_XMDSHA256_SVDW_NU_ SuiteID = "BLS12381G2_XMD:SHA-256_SVDW_NU_"
+	BLS12381G2_XMDSHA256_SVDW_RO_ SuiteID = "BLS12381G2_XMD:SHA-256_SVDW_RO_"
+)
+
+// Suites named as in draft-irtf-cfrg-hash-to-curve-05. These suites hash to
+// field elements using HKDF, so their outputs differ from the RFC 9380 suites.
+//
+// Deprecated: these suites are kept to reproduce draft-05 outputs; use the
+// suites named as in RFC 9380 instead.
 const (
 	P256_SHA256_SSWU_NU_           SuiteID = "P256-SHA256-SSWU-NU-"
 	P256_SHA256_SSWU_RO_           SuiteID = "P256-SHA256-SSWU-RO-"
@@ -43,35 +95,115 @@ const (
 	BLS12381G1_SHA256_SSWU_RO_     SuiteID = "BLS12381G1-SHA256-SSWU-RO-"
 	BLS12381G1_SHA256_SVDW_NU_     SuiteID = "BLS12381G1-SHA256-SVDW-NU-"
 	BLS12381G1_SHA256_SVDW_RO_     SuiteID = "BLS12381G1-SHA256-SVDW-RO-"
+	BLS12381G2_SHA256_SSWU_NU_     SuiteID = "BLS12381G2-SHA256-SSWU-NU-"
+	BLS12381G2_SHA256_SSWU_RO_     SuiteID = "BLS12381G2-SHA256-SSWU-RO-"
+	BLS12381G2_SHA256_SVDW_NU_     SuiteID = "BLS12381G2-SHA256-SVDW-NU-"
+	BLS12381G2_SHA256_SVDW_RO_     SuiteID = "BLS12381G2-SHA256-SVDW-RO-"
 )
 
+func (id SuiteID) String() string { return string(id) }
+
+// IsDeprecated returns true if the suite is named as in draft-05.
+func (id SuiteID) IsDeprecated() bool {
+	s, ok := supportedSuitesID[id]
+	return ok && s.Draft05
+}
+
+// IsStandard returns true if the suite is defined in RFC 9380.
+func (id SuiteID) IsStandard() bool {
+	s, ok := supportedSuitesID[id]
+	return ok && !s.Draft05 && !s.NonStandard
+}
+
+// ParseSuiteID returns the SuiteID named by s, or an error if no suite is
+// registered under that name. Deprecated names are accepted too.
+func ParseSuiteID(s string) (SuiteID, error) {
+	id := SuiteID(s)
+	if _, ok := supportedSuitesID[id]; !ok {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedSuite, s)
+	}
+	return id, nil
+}
+
+// Suites returns the identifiers of the supported suites sorted by name,
+// excluding the deprecated ones.
+func Suites() []SuiteID {
+	ids := make([]SuiteID, 0, len(supportedSuitesID))
+	for id, s := range supportedSuitesID {
+		if !s.Draft05 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 // Get returns a HashToPoint based on the SuiteID, otherwise returns an error
 // if the SuiteID is not supported or invalid.
-func (id SuiteID) Get() (HashToPoint, error) {
+func (id SuiteID) Get() (HashToPoint, error) { return id.GetBackend(GF.BigInt) }
+
+// GetBackend is like Get but the field of the curve uses the backend b.
+func (id SuiteID) GetBackend(b GF.Backend) (HashToPoint, error) {
 	if s, ok := supportedSuitesID[id]; ok {
-		E := s.E.Get()
-		H := s.H.New
-		Z := E.Field().Elt(s.Z)
-		m := s.Map.Get(E, Z, s.Sgn0, s.Iso)
-		e := &encoding{E, H, s.L, m, s.RO}
+		E, err := s.E.TryGetBackend(b)
+		if err != nil {
+			return nil, err
+		}
+		var Z GF.Elt
+		if s.Z != nil {
+			Z = E.Field().Elt(s.Z)
+		}
+		var iso func() C.Isogeny
+		if s.Iso {
+			isogeny, err := C.IsogenyTo(E)
+			if err != nil {
+				return nil, err
+			}
+			iso = func() C.Isogeny { return isogeny }
+		}
+		m, err := s.Map.TryGet(E, Z, s.Sgn0, iso)
+		if err != nil {
+			return nil, err
+		}
+		S, err := s.E.ScalarFieldE(GF.BigInt)
+		if err != nil {
+			return nil, err
+		}
+		var h, hs FieldHasher
+		switch {
+		case s.Draft05:
+			h = &fieldHasherDraft05{E.Field(), s.H, s.L, s.RO}
+			hs = &fieldHasherDraft05{S, s.H, s.L, s.RO}
+		case s.XOF != 0:
+			h = NewFieldHasherXOF(E.Field(), s.XOF, s.K)
+			hs = NewFieldHasherXOF(S, s.XOF, s.K)
+		default:
+			h = NewFieldHasherXMD(E.Field(), s.H, s.K)
+			hs = NewFieldHasherXMD(S, s.H, s.K)
+		}
+		e := &encoding{E, h, hs, m, s.RO}
 		if s.RO {
 			return &hashToCurve{e}, nil
 		}
 		return &encodeToCurve{e}, nil
 	}
-	return nil, fmt.Errorf("Suite: %v not supported", id)
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedSuite, id)
 }
 
 type params struct {
-	ID   SuiteID
-	E    C.CurveID
-	H    crypto.Hash
-	Map  M.ID
-	Sgn0 GF.Sgn0ID
-	L    uint
-	Z    int
-	Iso  func() C.Isogeny
-	RO   bool
+	ID          SuiteID
+	E           C.CurveID
+	H           crypto.Hash
+	XOF         XOF
+	Map         M.ID
+	Sgn0        GF.Sgn0ID
+	K           uint
+	Z           interface{}
+	Iso         bool // Iso suites map to an isogenous curve, see C.IsogenyTo.
+	RO          bool
+	Draft05     bool // Draft05 suites use HKDF with output length L.
+	NonStandard bool // NonStandard suites are not defined in RFC 9380.
+	L           uint
 }
 
 func (id SuiteID) register(s *params) {
@@ -84,33 +216,78 @@ var supportedSuitesID map[SuiteID]params
 func init() {
 	supportedSuitesID = make(map[SuiteID]params)
 	sha256 := crypto.SHA256
+	sha384 := crypto.SHA384
 	sha512 := crypto.SHA512
-	P256_SHA256_SSWU_NU_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: false, Z: -10})
-	P256_SHA256_SSWU_RO_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: true, Z: -10})
-	P256_SHA256_SVDW_NU_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: false})
-	P256_SHA256_SVDW_RO_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: true})
-	P384_SHA512_SSWU_NU_.register(&params{E: C.P384, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 72, RO: false, Z: -12})
-	P384_SHA512_SSWU_RO_.register(&params{E: C.P384, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 72, RO: true, Z: -12})
-	P384_SHA512_SVDW_NU_.register(&params{E: C.P384, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 72, RO: false})
-	P384_SHA512_SVDW_RO_.register(&params{E: C.P384, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 72, RO: true})
-	P521_SHA512_SSWU_NU_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 96, RO: false, Z: -4})
-	P521_SHA512_SSWU_RO_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 96, RO: true, Z: -4})
-	P521_SHA512_SVDW_NU_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 96, RO: false})
-	P521_SHA512_SVDW_RO_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 96, RO: true})
-	Curve25519_SHA256_ELL2_NU_.register(&params{E: C.Curve25519, H: sha256, Map: M.ELL2, Sgn0: GF.SignLE, L: 48, RO: false})
-	Curve25519_SHA256_ELL2_RO_.register(&params{E: C.Curve25519, H: sha256, Map: M.ELL2, Sgn0: GF.SignLE, L: 48, RO: true})
-	Edwards25519_SHA256_EDELL2_NU_.register(&params{E: C.Edwards25519, H: sha256, Map: M.EDELL2, Sgn0: GF.SignLE, L: 48, RO: false})
-	Edwards25519_SHA256_EDELL2_RO_.register(&params{E: C.Edwards25519, H: sha256, Map: M.EDELL2, Sgn0: GF.SignLE, L: 48, RO: true})
-	Curve448_SHA512_ELL2_NU_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, L: 84, RO: false})
-	Curve448_SHA512_ELL2_RO_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, L: 84, RO: true})
-	Edwards448_SHA512_EDELL2_NU_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, L: 84, RO: false})
-	Edwards448_SHA512_EDELL2_RO_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, L: 84, RO: true})
-	SECP256k1_SHA256_SSWU_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: false, Z: -11, Iso: C.GetSECP256K1Isogeny})
-	SECP256k1_SHA256_SSWU_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: true, Z: -11, Iso: C.GetSECP256K1Isogeny})
-	SECP256k1_SHA256_SVDW_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: false})
-	SECP256k1_SHA256_SVDW_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: true})
-	BLS12381G1_SHA256_SSWU_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: false, Z: 11, Iso: C.GetBLS12381G1Isogeny})
-	BLS12381G1_SHA256_SSWU_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: true, Z: 11, Iso: C.GetBLS12381G1Isogeny})
-	BLS12381G1_SHA256_SVDW_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: false})
-	BLS12381G1_SHA256_SVDW_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: true})
+	P256_XMDSHA256_SSWU_NU_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: false, Z: -10})
+	P256_XMDSHA256_SSWU_RO_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: true, Z: -10})
+	P384_XMDSHA384_SSWU_NU_.register(&params{E: C.P384, H: sha384, Map: M.SSWU, Sgn0: GF.SignLE, K: 192, RO: false, Z: -12})
+	P384_XMDSHA384_SSWU_RO_.register(&params{E: C.P384, H: sha384, Map: M.SSWU, Sgn0: GF.SignLE, K: 192, RO: true, Z: -12})
+	P521_XMDSHA512_SSWU_NU_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, K: 256, RO: false, Z: -4})
+	P521_XMDSHA512_SSWU_RO_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, K: 256, RO: true, Z: -4})
+	Curve25519_XMDSHA512_ELL2_NU_.register(&params{E: C.Curve25519, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, K: 128, RO: false})
+	Curve25519_XMDSHA512_ELL2_RO_.register(&params{E: C.Curve25519, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, K: 128, RO: true})
+	Edwards25519_XMDSHA512_ELL2_NU_.register(&params{E: C.Edwards25519, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, K: 128, RO: false})
+	Edwards25519_XMDSHA512_ELL2_RO_.register(&params{E: C.Edwards25519, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, K: 128, RO: true})
+	Curve448_XOFSHAKE256_ELL2_NU_.register(&params{E: C.Curve448, XOF: SHAKE256, Map: M.ELL2, Sgn0: GF.SignLE, K: 224, RO: false})
+	Curve448_XOFSHAKE256_ELL2_RO_.register(&params{E: C.Curve448, XOF: SHAKE256, Map: M.ELL2, Sgn0: GF.SignLE, K: 224, RO: true})
+	Edwards448_XOFSHAKE256_ELL2_NU_.register(&params{E: C.Edwards448, XOF: SHAKE256, Map: M.EDELL2, Sgn0: GF.SignLE, K: 224, RO: false})
+	Edwards448_XOFSHAKE256_ELL2_RO_.register(&params{E: C.Edwards448, XOF: SHAKE256, Map: M.EDELL2, Sgn0: GF.SignLE, K: 224, RO: true})
+	SECP256K1_XMDSHA256_SSWU_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: false, Z: -11, Iso: true})
+	SECP256K1_XMDSHA256_SSWU_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: true, Z: -11, Iso: true})
+	BLS12381G1_XMDSHA256_SSWU_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: false, Z: 11, Iso: true})
+	BLS12381G1_XMDSHA256_SSWU_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: true, Z: 11, Iso: true})
+	BLS12381G2_XMDSHA256_SSWU_NU_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: false, Z: []interface{}{-2, -1}, Iso: true})
+	BLS12381G2_XMDSHA256_SSWU_RO_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, K: 128, RO: true, Z: []interface{}{-2, -1}, Iso: true})
+
+	// Suites not defined in RFC 9380.
+	P256_XMDSHA256_SVDW_NU_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: false, NonStandard: true})
+	P256_XMDSHA256_SVDW_RO_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: true, NonStandard: true})
+	P384_XMDSHA384_SVDW_NU_.register(&params{E: C.P384, H: sha384, Map: M.SVDW, Sgn0: GF.SignLE, K: 192, RO: false, NonStandard: true})
+	P384_XMDSHA384_SVDW_RO_.register(&params{E: C.P384, H: sha384, Map: M.SVDW, Sgn0: GF.SignLE, K: 192, RO: true, NonStandard: true})
+	P521_XMDSHA512_SVDW_NU_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, K: 256, RO: false, NonStandard: true})
+	P521_XMDSHA512_SVDW_RO_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, K: 256, RO: true, NonStandard: true})
+	Curve448_XMDSHA512_ELL2_NU_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, K: 224, RO: false, NonStandard: true})
+	Curve448_XMDSHA512_ELL2_RO_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2, Sgn0: GF.SignLE, K: 224, RO: true, NonStandard: true})
+	Edwards448_XMDSHA512_ELL2_NU_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, K: 224, RO: false, NonStandard: true})
+	Edwards448_XMDSHA512_ELL2_RO_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2, Sgn0: GF.SignLE, K: 224, RO: true, NonStandard: true})
+	SECP256K1_XMDSHA256_SVDW_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: false, NonStandard: true})
+	SECP256K1_XMDSHA256_SVDW_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: true, NonStandard: true})
+	BLS12381G1_XMDSHA256_SVDW_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: false, NonStandard: true})
+	BLS12381G1_XMDSHA256_SVDW_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: true, NonStandard: true})
+	BLS12381G2_XMDSHA256_SVDW_NU_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: false, NonStandard: true})
+	BLS12381G2_XMDSHA256_SVDW_RO_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, K: 128, RO: true, NonStandard: true})
+
+	// draft-05 suites.
+	P256_SHA256_SSWU_NU_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: false, Z: -10, Draft05: true})
+	P256_SHA256_SSWU_RO_.register(&params{E: C.P256, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: true, Z: -10, Draft05: true})
+	P256_SHA256_SVDW_NU_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: false, Draft05: true})
+	P256_SHA256_SVDW_RO_.register(&params{E: C.P256, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: true, Draft05: true})
+	P384_SHA512_SSWU_NU_.register(&params{E: C.P384, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 72, RO: false, Z: -12, Draft05: true})
+	P384_SHA512_SSWU_RO_.register(&params{E: C.P384, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 72, RO: true, Z: -12, Draft05: true})
+	P384_SHA512_SVDW_NU_.register(&params{E: C.P384, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 72, RO: false, Draft05: true})
+	P384_SHA512_SVDW_RO_.register(&params{E: C.P384, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 72, RO: true, Draft05: true})
+	P521_SHA512_SSWU_NU_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 96, RO: false, Z: -4, Draft05: true})
+	P521_SHA512_SSWU_RO_.register(&params{E: C.P521, H: sha512, Map: M.SSWU, Sgn0: GF.SignLE, L: 96, RO: true, Z: -4, Draft05: true})
+	P521_SHA512_SVDW_NU_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 96, RO: false, Draft05: true})
+	P521_SHA512_SVDW_RO_.register(&params{E: C.P521, H: sha512, Map: M.SVDW, Sgn0: GF.SignLE, L: 96, RO: true, Draft05: true})
+	Curve25519_SHA256_ELL2_NU_.register(&params{E: C.Curve25519, H: sha256, Map: M.ELL2Draft05, Sgn0: GF.SignLE, L: 48, RO: false, Draft05: true})
+	Curve25519_SHA256_ELL2_RO_.register(&params{E: C.Curve25519, H: sha256, Map: M.ELL2Draft05, Sgn0: GF.SignLE, L: 48, RO: true, Draft05: true})
+	Edwards25519_SHA256_EDELL2_NU_.register(&params{E: C.Edwards25519, H: sha256, Map: M.EDELL2Draft05, Sgn0: GF.SignLE, L: 48, RO: false, Draft05: true})
+	Edwards25519_SHA256_EDELL2_RO_.register(&params{E: C.Edwards25519, H: sha256, Map: M.EDELL2Draft05, Sgn0: GF.SignLE, L: 48, RO: true, Draft05: true})
+	Curve448_SHA512_ELL2_NU_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2Draft05, Sgn0: GF.SignLE, L: 84, RO: false, Draft05: true})
+	Curve448_SHA512_ELL2_RO_.register(&params{E: C.Curve448, H: sha512, Map: M.ELL2Draft05, Sgn0: GF.SignLE, L: 84, RO: true, Draft05: true})
+	Edwards448_SHA512_EDELL2_NU_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2Draft05, Sgn0: GF.SignLE, L: 84, RO: false, Draft05: true})
+	Edwards448_SHA512_EDELL2_RO_.register(&params{E: C.Edwards448, H: sha512, Map: M.EDELL2Draft05, Sgn0: GF.SignLE, L: 84, RO: true, Draft05: true})
+	SECP256k1_SHA256_SSWU_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: false, Z: -11, Iso: true, Draft05: true})
+	SECP256k1_SHA256_SSWU_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SSWU, Sgn0: GF.SignLE, L: 48, RO: true, Z: -11, Iso: true, Draft05: true})
+	SECP256k1_SHA256_SVDW_NU_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: false, Draft05: true})
+	SECP256k1_SHA256_SVDW_RO_.register(&params{E: C.SECP256K1, H: sha256, Map: M.SVDW, Sgn0: GF.SignLE, L: 48, RO: true, Draft05: true})
+	BLS12381G1_SHA256_SSWU_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: false, Z: 11, Iso: true, Draft05: true})
+	BLS12381G1_SHA256_SSWU_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: true, Z: 11, Iso: true, Draft05: true})
+	BLS12381G1_SHA256_SVDW_NU_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: false, Draft05: true})
+	BLS12381G1_SHA256_SVDW_RO_.register(&params{E: C.BLS12381G1, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: true, Draft05: true})
+	BLS12381G2_SHA256_SSWU_NU_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: false, Z: []interface{}{-2, -1}, Iso: true, Draft05: true})
+	BLS12381G2_SHA256_SSWU_RO_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SSWU, Sgn0: GF.SignBE, L: 64, RO: true, Z: []interface{}{-2, -1}, Iso: true, Draft05: true})
+	BLS12381G2_SHA256_SVDW_NU_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: false, Draft05: true})
+	BLS12381G2_SHA256_SVDW_RO_.register(&params{E: C.BLS12381G2, H: sha256, Map: M.SVDW, Sgn0: GF.SignBE, L: 64, RO: true, Draft05: true})
 }